@@ -3,14 +3,19 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"regexp"
 	"time"
 
 	"github.com/flant/kubedog"
+	"github.com/flant/kubedog/pkg/display"
 	"github.com/flant/kubedog/pkg/kube"
 	"github.com/flant/kubedog/pkg/tracker"
 	"github.com/flant/kubedog/pkg/trackers/follow"
 	"github.com/flant/kubedog/pkg/trackers/rollout"
+	"github.com/flant/kubedog/pkg/trackers/rollout/multitrack"
 	"github.com/spf13/cobra"
 )
 
@@ -23,6 +28,23 @@ func main() {
 	var logsSince string
 	var kubeContext string
 	var kubeConfig string
+	var outputFormat string
+	var logTimestamps string
+	var logTimestampsFormat string
+	var logsReorderLatency time.Duration
+	var structuredLogs bool
+	var structuredLogFields []string
+
+	// logsReorderPrinter merges logs of all pods in timestamps order when --logs-reorder-latency is set
+	var logsReorderPrinter *display.LogsReorderPrinter
+
+	// exit prints buffered log lines before exit
+	exit := func(code int) {
+		if logsReorderPrinter != nil {
+			logsReorderPrinter.Close()
+		}
+		os.Exit(code)
+	}
 
 	makeTrackerOptions := func(mode string) tracker.Options {
 		// rollout track defaults
@@ -50,6 +72,9 @@ func main() {
 			Timeout:      time.Second * time.Duration(timeout),
 			LogsFromTime: logsFromTime,
 		}
+		if logsReorderPrinter != nil {
+			opts.Printer = logsReorderPrinter
+		}
 
 		return opts
 	}
@@ -58,16 +83,45 @@ func main() {
 		err := kube.Init(kube.InitOptions{KubeContext: kubeContext, KubeConfig: kubeConfig})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Unable to initialize kube: %s\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
-	rootCmd := &cobra.Command{Use: "kubedog"}
+	rootCmd := &cobra.Command{
+		Use: "kubedog",
+		PersistentPreRun: func(_ *cobra.Command, _ []string) {
+			if err := display.SetOutputFormat(outputFormat); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				exit(2)
+			}
+
+			logTimestampsMode, err := display.ParseLogTimestampsMode(logTimestamps)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				exit(2)
+			}
+			display.SetLogTimestamps(display.LogTimestamps{Mode: logTimestampsMode, Format: logTimestampsFormat})
+			display.SetStructuredLogs(display.StructuredLogs{Enabled: structuredLogs, Fields: structuredLogFields})
+
+			if logsReorderLatency > 0 {
+				logsReorderPrinter = display.NewLogsReorderPrinter(display.DefaultPrinter(), display.LogsReorderOptions{
+					Latency:      logsReorderLatency,
+					FlushOnClose: true,
+				})
+			}
+		},
+	}
 	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "default", "If present, the namespace scope of a resource.")
 	rootCmd.PersistentFlags().IntVarP(&timeoutSeconds, "timeout", "t", -1, "Timeout of operation in seconds. 0 is wait forever. Default is 0.")
 	rootCmd.PersistentFlags().StringVarP(&logsSince, "logs-since", "", "now", "A duration like 30s, 5m, or 2h to start log records from the past. 'all' to show all logs and 'now' to display only new records (default).")
 	rootCmd.PersistentFlags().StringVarP(&kubeContext, "kube-context", "", os.Getenv("KUBEDOG_KUBE_CONTEXT"), "The name of the kubeconfig context to use (can be set with $KUBEDOG_KUBE_CONTEXT).")
 	rootCmd.PersistentFlags().StringVarP(&kubeConfig, "kube-config", "", os.Getenv("KUBEDOG_KUBE_CONFIG"), "Path to the kubeconfig file (can be set with $KUBEDOG_KUBE_CONFIG).")
+	rootCmd.PersistentFlags().StringVarP(&logTimestamps, "log-timestamps", "", "none", "Show timestamps of log lines: none, utc, local or relative to the start of tracking.")
+	rootCmd.PersistentFlags().StringVarP(&logTimestampsFormat, "log-timestamps-format", "", display.DefaultLogTimestampsFormat, "Go time layout of utc and local log timestamps.")
+	rootCmd.PersistentFlags().BoolVarP(&structuredLogs, "structured-logs", "", false, "Show JSON log lines compactly as colored level and message, other log lines are shown unchanged.")
+	rootCmd.PersistentFlags().StringSliceVarP(&structuredLogFields, "structured-log-fields", "", nil, "Comma separated fields of JSON log lines shown after the message with --structured-logs.")
+	rootCmd.PersistentFlags().DurationVarP(&logsReorderLatency, "logs-reorder-latency", "", 0, "Merge log lines of all pods and containers in timestamps order, holding every line up to the given duration like 500ms or 2s. Disabled by default.")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", display.TextOutputFormat, "Output format: text or json. With json every message, log line and status report is printed as a separate JSON object line.")
 
 	versionCmd := &cobra.Command{
 		Use: "version",
@@ -77,163 +131,566 @@ func main() {
 	}
 	rootCmd.AddCommand(versionCmd)
 
+	var labelSelector string
+	nameOrSelectorArgs := func(cmd *cobra.Command, args []string) error {
+		if labelSelector != "" {
+			if len(args) > 0 {
+				return fmt.Errorf("NAME and --selector cannot be used together")
+			}
+			return nil
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	}
+
 	followCmd := &cobra.Command{Use: "follow"}
+	followCmd.PersistentFlags().StringVarP(&labelSelector, "selector", "l", "", "Label selector to follow all matching resources, including resources created later, instead of NAME.")
 	rootCmd.AddCommand(followCmd)
 
 	followCmd.AddCommand(&cobra.Command{
-		Use:   "job NAME",
+		Use:   "job [NAME]",
 		Short: "Follow Job",
-		Args:  cobra.MinimumNArgs(1),
+		Args:  nameOrSelectorArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			name := args[0]
 			initKube()
-			err := follow.TrackJob(name, namespace, kube.Kubernetes, makeTrackerOptions("follow"))
+			var err error
+			if labelSelector != "" {
+				err = follow.TrackBySelector("job", labelSelector, namespace, kube.Kubernetes, makeTrackerOptions("follow"))
+			} else {
+				err = follow.TrackJob(args[0], namespace, kube.Kubernetes, makeTrackerOptions("follow"))
+			}
 			if err != nil {
 				fmt.Fprintln(os.Stderr, err)
-				os.Exit(1)
+				exit(1)
 			}
 		},
 	})
 	followCmd.AddCommand(&cobra.Command{
-		Use:   "deployment NAME",
+		Use:   "deployment [NAME]",
 		Short: "Follow Deployment",
-		Args:  cobra.MinimumNArgs(1),
+		Args:  nameOrSelectorArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			name := args[0]
 			initKube()
-			err := follow.TrackDeployment(name, namespace, kube.Kubernetes, makeTrackerOptions("follow"))
+			var err error
+			if labelSelector != "" {
+				err = follow.TrackBySelector("deploy", labelSelector, namespace, kube.Kubernetes, makeTrackerOptions("follow"))
+			} else {
+				err = follow.TrackDeployment(args[0], namespace, kube.Kubernetes, makeTrackerOptions("follow"))
+			}
 			if err != nil {
 				fmt.Fprintln(os.Stderr, err)
-				os.Exit(1)
+				exit(1)
 			}
 		},
 	})
 	followCmd.AddCommand(&cobra.Command{
-		Use:   "statefulset NAME",
+		Use:   "statefulset [NAME]",
 		Short: "Follow StatefulSet",
-		Args:  cobra.MinimumNArgs(1),
+		Args:  nameOrSelectorArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			name := args[0]
 			initKube()
-			err := follow.TrackStatefulSet(name, namespace, kube.Kubernetes, makeTrackerOptions("follow"))
+			var err error
+			if labelSelector != "" {
+				err = follow.TrackBySelector("sts", labelSelector, namespace, kube.Kubernetes, makeTrackerOptions("follow"))
+			} else {
+				err = follow.TrackStatefulSet(args[0], namespace, kube.Kubernetes, makeTrackerOptions("follow"))
+			}
 			if err != nil {
 				fmt.Fprintln(os.Stderr, err)
-				os.Exit(1)
+				exit(1)
 			}
 		},
 	})
 	followCmd.AddCommand(&cobra.Command{
-		Use:   "daemonset NAME",
+		Use:   "daemonset [NAME]",
 		Short: "Follow DaemonSet",
-		Args:  cobra.MinimumNArgs(1),
+		Args:  nameOrSelectorArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			name := args[0]
 			initKube()
-			err := follow.TrackDaemonSet(name, namespace, kube.Kubernetes, makeTrackerOptions("follow"))
+			var err error
+			if labelSelector != "" {
+				err = follow.TrackBySelector("ds", labelSelector, namespace, kube.Kubernetes, makeTrackerOptions("follow"))
+			} else {
+				err = follow.TrackDaemonSet(args[0], namespace, kube.Kubernetes, makeTrackerOptions("follow"))
+			}
 			if err != nil {
 				fmt.Fprintln(os.Stderr, err)
-				os.Exit(1)
+				exit(1)
 			}
 		},
 	})
 	followCmd.AddCommand(&cobra.Command{
-		Use:   "pod NAME",
+		Use:   "pod [NAME]",
 		Short: "Follow Pod",
-		Args:  cobra.MinimumNArgs(1),
+		Args:  nameOrSelectorArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			name := args[0]
 			initKube()
-			err := follow.TrackPod(name, namespace, kube.Kubernetes, makeTrackerOptions("follow"))
+			var err error
+			if labelSelector != "" {
+				err = follow.TrackBySelector("po", labelSelector, namespace, kube.Kubernetes, makeTrackerOptions("follow"))
+			} else {
+				err = follow.TrackPod(args[0], namespace, kube.Kubernetes, makeTrackerOptions("follow"))
+			}
 			if err != nil {
 				fmt.Fprintln(os.Stderr, err)
-				os.Exit(1)
+				exit(1)
 			}
 		},
 	})
 
+	var includeFilters, excludeFilters []string
+	followNamespaceCmd := &cobra.Command{
+		Use:   "namespace [NAMESPACE]",
+		Short: "Follow all resources in the namespace",
+		Long: `Follow all Deployments, StatefulSets, DaemonSets, Jobs and Pods not managed
+by them in the namespace (--namespace option by default), including resources
+created while following.
+
+Use --include and --exclude options to filter resources by kind and name
+glob pattern: KIND, KIND/PATTERN or */PATTERN, where KIND is one of po, deploy,
+sts, ds or job, e.g. --include deploy/api-* --exclude */*-canary.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			opts := follow.NamespaceOptions{LabelSelector: labelSelector}
+
+			for _, filter := range includeFilters {
+				resourceFilter, err := follow.ParseResourceFilter(filter)
+				if err != nil {
+					fmt.Fprintln(os.Stderr, err)
+					exit(2)
+				}
+				opts.Include = append(opts.Include, resourceFilter)
+			}
+			for _, filter := range excludeFilters {
+				resourceFilter, err := follow.ParseResourceFilter(filter)
+				if err != nil {
+					fmt.Fprintln(os.Stderr, err)
+					exit(2)
+				}
+				opts.Exclude = append(opts.Exclude, resourceFilter)
+			}
+
+			followNamespace := namespace
+			if len(args) > 0 {
+				followNamespace = args[0]
+			}
+
+			initKube()
+			opts.Options = makeTrackerOptions("follow")
+			err := follow.TrackNamespace(followNamespace, kube.Kubernetes, opts)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				exit(1)
+			}
+		},
+	}
+	followNamespaceCmd.Flags().StringArrayVarP(&includeFilters, "include", "", nil, "Follow only resources matching KIND[/PATTERN] filter, can be specified multiple times.")
+	followNamespaceCmd.Flags().StringArrayVarP(&excludeFilters, "exclude", "", nil, "Skip resources matching KIND[/PATTERN] filter, can be specified multiple times.")
+	followCmd.AddCommand(followNamespaceCmd)
+
 	rolloutCmd := &cobra.Command{Use: "rollout"}
 	rootCmd.AddCommand(rolloutCmd)
-	trackCmd := &cobra.Command{Use: "track"}
+	var manifestsFile string
+	var failOnLogRegex string
+	var succeedOnLogRegex string
+	var unschedulableTimeoutSeconds int
+	var maxRestarts int
+	// hasMultitrackOptions is true when resources should be tracked by the multitracker to apply options
+	// which are supported only there: log lines checks, unschedulable pods timeout and max restarts
+	hasMultitrackOptions := func() bool {
+		return failOnLogRegex != "" || succeedOnLogRegex != "" || unschedulableTimeoutSeconds > 0 || maxRestarts >= 0
+	}
+	multitrackResources := func(specs multitrack.MultitrackSpecs) {
+		if err := setSpecsLogRegexes(&specs, failOnLogRegex, succeedOnLogRegex); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			exit(2)
+		}
+		setSpecsUnschedulableTimeout(&specs, unschedulableTimeoutSeconds)
+		if maxRestarts >= 0 {
+			setSpecsMaxRestarts(&specs, maxRestarts)
+		}
+
+		initKube()
+		err := multitrack.Multitrack(kube.Kubernetes, specs, multitrack.MultitrackOptions{Options: makeTrackerOptions("track")})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			exit(1)
+		}
+	}
+	trackCmd := &cobra.Command{
+		Use:   "track [KIND/NAME...]",
+		Short: "Track resources till ready",
+		Long: `Track resources till ready.
+
+Use one of the subcommands to track resources of one kind, pass resources
+as KIND/NAME arguments (po, deploy, sts, ds or job), e.g.
+
+  kubedog rollout track deploy/api sts/db job/migrate
+
+or pass kubernetes manifests with --file option to track all Pods, Deployments,
+StatefulSets, DaemonSets and Jobs described there. Use "-" to read manifests
+from stdin. With --selector option all Deployments, StatefulSets, DaemonSets,
+Jobs and Pods matching the label selector are tracked, pods managed by tracked
+controllers are tracked by their controllers. Arguments, manifests and selector
+can be used together, a resource passed several times is tracked once with
+the options from its manifest annotations.
+
+Multiple resources are tracked at once with a combined status report.
+Exit code is 0 when all resources are ready, 1 when tracking of any resource
+failed and 2 when arguments or manifests file are invalid.`,
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if manifestsFile == "" && labelSelector == "" && len(args) == 0 {
+				cmd.Help()
+				return
+			}
+
+			specs, err := parseResourcesArgs(args, namespace)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				exit(2)
+			}
+
+			if manifestsFile != "" {
+				manifestsSpecs, err := readManifestsSpecs(manifestsFile, namespace)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Bad manifests file %s: %s\n", manifestsFile, err)
+					exit(2)
+				}
+
+				addSpecs(&specs.Pods, manifestsSpecs.Pods...)
+				addSpecs(&specs.Deployments, manifestsSpecs.Deployments...)
+				addSpecs(&specs.StatefulSets, manifestsSpecs.StatefulSets...)
+				addSpecs(&specs.DaemonSets, manifestsSpecs.DaemonSets...)
+				addSpecs(&specs.Jobs, manifestsSpecs.Jobs...)
+			}
+
+			if labelSelector != "" {
+				for _, group := range []*[]multitrack.MultitrackSpec{&specs.Deployments, &specs.StatefulSets, &specs.DaemonSets, &specs.Jobs, &specs.Pods} {
+					*group = append(*group, multitrack.MultitrackSpec{LabelSelector: labelSelector, Namespace: namespace})
+				}
+			}
+
+			if err := multitrack.ValidateSpecs(specs); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				exit(2)
+			}
+
+			multitrackResources(specs)
+		},
+	}
+	trackCmd.Flags().StringVarP(&manifestsFile, "file", "f", "", "Path to the kubernetes manifests file, \"-\" to read from stdin.")
+	trackCmd.PersistentFlags().StringVarP(&labelSelector, "selector", "l", "", "Label selector to track all matching resources instead of NAME.")
+	trackCmd.PersistentFlags().StringVarP(&failOnLogRegex, "fail-on-log-regex", "", "", "Fail the resource when a container log line matches the regex, e.g. 'FATAL|panic:'.")
+	trackCmd.PersistentFlags().StringVarP(&succeedOnLogRegex, "succeed-on-log-regex", "", "", "Consider the resource ready when a container log line matches the regex.")
+	trackCmd.PersistentFlags().IntVarP(&unschedulableTimeoutSeconds, "unschedulable-timeout", "", 0, "Fail the resource when its pod cannot be scheduled for the given number of seconds. 0 is wait forever: FailedScheduling events do not fail the resource.")
+	trackCmd.PersistentFlags().IntVarP(&maxRestarts, "max-restarts", "", -1, "Fail the resource when its containers restart more than the given number of times since the start of tracking. -1 is no limit.")
 	rolloutCmd.AddCommand(trackCmd)
 
 	trackCmd.AddCommand(&cobra.Command{
-		Use:   "job NAME",
+		Use:   "job [NAME...]",
 		Short: "Track Job till job is done",
-		Args:  cobra.MinimumNArgs(1),
+		Args:  nameOrSelectorArgs,
 		Run: func(cmd *cobra.Command, args []string) {
+			if labelSelector != "" {
+				multitrackResources(multitrack.MultitrackSpecs{
+					Jobs: []multitrack.MultitrackSpec{{LabelSelector: labelSelector, Namespace: namespace}},
+				})
+				return
+			}
+
+			if len(args) > 1 || hasMultitrackOptions() {
+				multitrackResources(multitrack.MultitrackSpecs{Jobs: namesSpecs(args, namespace)})
+				return
+			}
+
 			name := args[0]
 			initKube()
 			err := rollout.TrackJobTillDone(name, namespace, kube.Kubernetes, makeTrackerOptions("track"))
 			if err != nil {
 				fmt.Fprintln(os.Stderr, err)
-				os.Exit(1)
+				exit(1)
 			}
 		},
 	})
 
 	trackCmd.AddCommand(&cobra.Command{
-		Use:   "deployment NAME",
+		Use:   "deployment [NAME...]",
 		Short: "Track Deployment till ready",
-		Args:  cobra.MinimumNArgs(1),
+		Args:  nameOrSelectorArgs,
 		Run: func(cmd *cobra.Command, args []string) {
+			if labelSelector != "" {
+				multitrackResources(multitrack.MultitrackSpecs{
+					Deployments: []multitrack.MultitrackSpec{{LabelSelector: labelSelector, Namespace: namespace}},
+				})
+				return
+			}
+
+			if len(args) > 1 || hasMultitrackOptions() {
+				multitrackResources(multitrack.MultitrackSpecs{Deployments: namesSpecs(args, namespace)})
+				return
+			}
+
 			name := args[0]
 			initKube()
 			err := rollout.TrackDeploymentTillReady(name, namespace, kube.Kubernetes, makeTrackerOptions("track"))
 			if err != nil {
 				fmt.Fprintln(os.Stderr, err)
-				os.Exit(1)
+				exit(1)
 			}
 		},
 	})
 
 	trackCmd.AddCommand(&cobra.Command{
-		Use:   "statefulset NAME",
+		Use:   "statefulset [NAME...]",
 		Short: "Track Statefulset till ready",
-		Args:  cobra.MinimumNArgs(1),
+		Args:  nameOrSelectorArgs,
 		Run: func(cmd *cobra.Command, args []string) {
+			if labelSelector != "" {
+				multitrackResources(multitrack.MultitrackSpecs{
+					StatefulSets: []multitrack.MultitrackSpec{{LabelSelector: labelSelector, Namespace: namespace}},
+				})
+				return
+			}
+
+			if len(args) > 1 || hasMultitrackOptions() {
+				multitrackResources(multitrack.MultitrackSpecs{StatefulSets: namesSpecs(args, namespace)})
+				return
+			}
+
 			name := args[0]
 			initKube()
 			err := rollout.TrackStatefulSetTillReady(name, namespace, kube.Kubernetes, makeTrackerOptions("track"))
 			if err != nil {
 				fmt.Fprintln(os.Stderr, err)
-				os.Exit(1)
+				exit(1)
 			}
 		},
 	})
 
 	trackCmd.AddCommand(&cobra.Command{
-		Use:   "daemonset NAME",
+		Use:   "daemonset [NAME...]",
 		Short: "Track DaemonSet till ready",
-		Args:  cobra.MinimumNArgs(1),
+		Args:  nameOrSelectorArgs,
 		Run: func(cmd *cobra.Command, args []string) {
+			if labelSelector != "" {
+				multitrackResources(multitrack.MultitrackSpecs{
+					DaemonSets: []multitrack.MultitrackSpec{{LabelSelector: labelSelector, Namespace: namespace}},
+				})
+				return
+			}
+
+			if len(args) > 1 || hasMultitrackOptions() {
+				multitrackResources(multitrack.MultitrackSpecs{DaemonSets: namesSpecs(args, namespace)})
+				return
+			}
+
 			name := args[0]
 			initKube()
 			err := rollout.TrackDaemonSetTillReady(name, namespace, kube.Kubernetes, makeTrackerOptions("track"))
 			if err != nil {
 				fmt.Fprintln(os.Stderr, err)
-				os.Exit(1)
+				exit(1)
 			}
 		},
 	})
 
 	trackCmd.AddCommand(&cobra.Command{
-		Use:   "pod NAME",
+		Use:   "pod [NAME...]",
 		Short: "Track Pod till ready",
-		Args:  cobra.MinimumNArgs(1),
+		Args:  nameOrSelectorArgs,
 		Run: func(cmd *cobra.Command, args []string) {
+			if labelSelector != "" {
+				multitrackResources(multitrack.MultitrackSpecs{
+					Pods: []multitrack.MultitrackSpec{{LabelSelector: labelSelector, Namespace: namespace}},
+				})
+				return
+			}
+
+			if len(args) > 1 || hasMultitrackOptions() {
+				multitrackResources(multitrack.MultitrackSpecs{Pods: namesSpecs(args, namespace)})
+				return
+			}
+
 			name := args[0]
 			initKube()
 			err := rollout.TrackPodTillReady(name, namespace, kube.Kubernetes, makeTrackerOptions("track"))
 			if err != nil {
 				fmt.Fprintln(os.Stderr, err)
-				os.Exit(1)
+				exit(1)
 			}
 		},
 	})
 
+	var multitrackSpecsFile string
+	multitrackCmd := &cobra.Command{
+		Use:   "multitrack",
+		Short: "Track multiple resources described in the specs file till ready",
+		Long: `Track multiple resources described in the specs file till ready.
+
+Specs file is a YAML or JSON document with pods, deployments, statefulSets,
+daemonSets and jobs lists of multitrack specs. Use "-" to read specs from stdin.
+Specs file with several specs of the same resource is rejected as invalid.
+
+Exit code is 0 when all resources are ready, 1 when tracking failed
+and 2 when specs file is invalid.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			specs, err := readMultitrackSpecs(multitrackSpecsFile, namespace)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Bad multitrack specs file %s: %s\n", multitrackSpecsFile, err)
+				exit(2)
+			}
+
+			initKube()
+			err = multitrack.Multitrack(kube.Kubernetes, specs, multitrack.MultitrackOptions{Options: makeTrackerOptions("track")})
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				exit(1)
+			}
+		},
+	}
+	multitrackCmd.Flags().StringVarP(&multitrackSpecsFile, "file", "f", "", "Path to the multitrack specs file, \"-\" to read from stdin.")
+	multitrackCmd.MarkFlagRequired("file")
+	rootCmd.AddCommand(multitrackCmd)
+
 	err := rootCmd.Execute()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		os.Exit(1)
+		exit(1)
+	}
+
+	if logsReorderPrinter != nil {
+		logsReorderPrinter.Close()
+	}
+}
+
+func openInput(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return ioutil.NopCloser(os.Stdin), nil
 	}
+	return os.Open(path)
+}
+
+// parseResourcesArgs makes specs of resources passed as KIND/NAME arguments
+func parseResourcesArgs(args []string, defaultNamespace string) (multitrack.MultitrackSpecs, error) {
+	specs := multitrack.MultitrackSpecs{}
+	for _, arg := range args {
+		kind, name, err := multitrack.ParseResourceKindName(arg)
+		if err != nil {
+			return multitrack.MultitrackSpecs{}, err
+		}
+
+		group, err := specs.KindSpecs(kind)
+		if err != nil {
+			return multitrack.MultitrackSpecs{}, err
+		}
+		addSpecs(group, multitrack.MultitrackSpec{ResourceName: name, Namespace: defaultNamespace})
+	}
+	return specs, nil
+}
+
+// addSpecs adds specs to the group of specs of one kind, the spec of the resource which is already added is replaced.
+// The same resource can be passed several times as argument or both as argument and in manifests,
+// it is tracked once then: specs of arguments have no options, while specs from manifests may have.
+func addSpecs(group *[]multitrack.MultitrackSpec, newSpecs ...multitrack.MultitrackSpec) {
+	for _, newSpec := range newSpecs {
+		isReplaced := false
+		for i, spec := range *group {
+			if spec.LabelSelector == "" && spec.ResourceName == newSpec.ResourceName && spec.Namespace == newSpec.Namespace {
+				(*group)[i] = newSpec
+				isReplaced = true
+				break
+			}
+		}
+
+		if !isReplaced {
+			*group = append(*group, newSpec)
+		}
+	}
+}
+
+// setSpecsUnschedulableTimeout sets UnschedulableTimeoutSeconds of all specs, which do not have it already
+func setSpecsUnschedulableTimeout(specs *multitrack.MultitrackSpecs, seconds int) {
+	for _, group := range []*[]multitrack.MultitrackSpec{&specs.Pods, &specs.Deployments, &specs.StatefulSets, &specs.DaemonSets, &specs.Jobs} {
+		for i := range *group {
+			spec := &(*group)[i]
+			if spec.UnschedulableTimeoutSeconds == 0 {
+				spec.UnschedulableTimeoutSeconds = seconds
+			}
+		}
+	}
+}
+
+// setSpecsMaxRestarts sets MaxRestarts of all specs, which do not have it already
+func setSpecsMaxRestarts(specs *multitrack.MultitrackSpecs, count int) {
+	for _, group := range []*[]multitrack.MultitrackSpec{&specs.Pods, &specs.Deployments, &specs.StatefulSets, &specs.DaemonSets, &specs.Jobs} {
+		for i := range *group {
+			spec := &(*group)[i]
+			if spec.MaxRestarts == nil {
+				spec.MaxRestarts = new(int)
+				*spec.MaxRestarts = count
+			}
+		}
+	}
+}
+
+// setSpecsLogRegexes sets FailOnLogRegex and SucceedOnLogRegex of all specs, which do not have them already
+func setSpecsLogRegexes(specs *multitrack.MultitrackSpecs, failOnLogRegex, succeedOnLogRegex string) error {
+	var failRegex, succeedRegex *regexp.Regexp
+	var err error
+
+	if failOnLogRegex != "" {
+		if failRegex, err = regexp.Compile(failOnLogRegex); err != nil {
+			return fmt.Errorf("bad --fail-on-log-regex %q: %s", failOnLogRegex, err)
+		}
+	}
+
+	if succeedOnLogRegex != "" {
+		if succeedRegex, err = regexp.Compile(succeedOnLogRegex); err != nil {
+			return fmt.Errorf("bad --succeed-on-log-regex %q: %s", succeedOnLogRegex, err)
+		}
+	}
+
+	for _, group := range []*[]multitrack.MultitrackSpec{&specs.Pods, &specs.Deployments, &specs.StatefulSets, &specs.DaemonSets, &specs.Jobs} {
+		for i := range *group {
+			spec := &(*group)[i]
+			if spec.FailOnLogRegex == nil {
+				spec.FailOnLogRegex = failRegex
+			}
+			if spec.SucceedOnLogRegex == nil {
+				spec.SucceedOnLogRegex = succeedRegex
+			}
+		}
+	}
+
+	return nil
+}
+
+// namesSpecs makes specs of resources passed as NAME arguments to the command of their kind
+func namesSpecs(names []string, defaultNamespace string) []multitrack.MultitrackSpec {
+	specs := []multitrack.MultitrackSpec{}
+	for _, name := range names {
+		addSpecs(&specs, multitrack.MultitrackSpec{ResourceName: name, Namespace: defaultNamespace})
+	}
+	return specs
+}
+
+func readMultitrackSpecs(path, defaultNamespace string) (multitrack.MultitrackSpecs, error) {
+	input, err := openInput(path)
+	if err != nil {
+		return multitrack.MultitrackSpecs{}, err
+	}
+	defer input.Close()
+
+	return multitrack.ReadMultitrackSpecs(input, defaultNamespace)
+}
+
+func readManifestsSpecs(path, defaultNamespace string) (multitrack.MultitrackSpecs, error) {
+	input, err := openInput(path)
+	if err != nil {
+		return multitrack.MultitrackSpecs{}, err
+	}
+	defer input.Close()
+
+	return multitrack.ReadSpecsFromManifests(input, defaultNamespace)
 }
@@ -15,6 +15,7 @@ var (
 	currentLogHeader = ""
 )
 
+// SetOut sets the writer of the default printer used by trackers without Printer in options
 func SetOut(out io.Writer) {
 	Out = out
 }
@@ -46,24 +47,35 @@ func SetLogHeader(logHeader string) {
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	if currentLogHeader != logHeader {
-		if currentLogHeader != "" {
-			fmt.Fprintln(Out)
+	writeLogHeader(Out, &currentLogHeader, logHeader)
+}
+
+func OutputLogLines(header string, logLines []LogLine) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	writeLogLines(Out, &currentLogHeader, inline(), logTimestamps, structuredLogs, header, logLines)
+}
+
+func writeLogHeader(out io.Writer, currentLogHeader *string, logHeader string) {
+	if *currentLogHeader != logHeader {
+		if *currentLogHeader != "" {
+			fmt.Fprintln(out)
 		}
-		fmt.Fprintf(Out, ">> %s\n", logHeader)
-		currentLogHeader = logHeader
+		fmt.Fprintf(out, ">> %s\n", logHeader)
+		*currentLogHeader = logHeader
 	}
 }
 
-func OutputLogLines(header string, logLines []LogLine) {
-	if inline() {
+func writeLogLines(out io.Writer, currentLogHeader *string, inline bool, timestamps LogTimestamps, structured StructuredLogs, header string, logLines []LogLine) {
+	if inline {
 		for _, line := range logLines {
-			fmt.Fprintf(Out, ">> %s: %s\n", header, line.Message)
+			fmt.Fprintf(out, ">> %s: %s\n", header, formatLogLine(timestamps, formatStructuredLogLine(structured, line)))
 		}
 	} else {
-		SetLogHeader(header)
+		writeLogHeader(out, currentLogHeader, header)
 		for _, line := range logLines {
-			fmt.Fprintln(Out, line.Message)
+			fmt.Fprintln(out, formatLogLine(timestamps, formatStructuredLogLine(structured, line)))
 		}
 	}
 }
@@ -0,0 +1,158 @@
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	TextOutputFormat = "text"
+	JSONOutputFormat = "json"
+)
+
+var outputFormat = TextOutputFormat
+
+// SetOutputFormat sets the format of the tracker output: text (default) or json.
+// In the json format every message is printed as a separate json object line (NDJSON).
+func SetOutputFormat(format string) error {
+	switch format {
+	case TextOutputFormat, JSONOutputFormat:
+		outputFormat = format
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q, expected one of: %s, %s", format, TextOutputFormat, JSONOutputFormat)
+	}
+}
+
+func IsJSONOutput() bool {
+	return outputFormat == JSONOutputFormat
+}
+
+type EventType string
+
+const (
+	AddedEvent           EventType = "added"
+	ReadyEvent           EventType = "ready"
+	SucceededEvent       EventType = "succeeded"
+	FailedEvent          EventType = "failed"
+	EventMsgEvent        EventType = "event"
+	AddedReplicaSetEvent EventType = "replicaset_added"
+	AddedPodEvent        EventType = "pod_added"
+	LogEvent             EventType = "log"
+	PodErrorEvent        EventType = "pod_error"
+	StatusReportEvent    EventType = "status"
+	MessageEvent         EventType = "message"
+
+	ContainerTerminatedEvent EventType = "container_terminated"
+	UnschedulableEvent       EventType = "unschedulable"
+	ContainerRestartedEvent  EventType = "container_restarted"
+)
+
+// Event is a json output object of the tracker feed callback
+type Event struct {
+	Type       EventType   `json:"type"`
+	Kind       string      `json:"kind"`
+	Namespace  string      `json:"namespace"`
+	Name       string      `json:"name"`
+	ReplicaSet string      `json:"replicaSet,omitempty"`
+	Pod        string      `json:"pod,omitempty"`
+	Container  string      `json:"container,omitempty"`
+	Timestamp  time.Time   `json:"timestamp"`
+	Message    string      `json:"message,omitempty"`
+	Status     interface{} `json:"status,omitempty"`
+}
+
+func (event Event) WithType(eventType EventType) Event {
+	event.Type = eventType
+	return event
+}
+
+func (event Event) WithMessage(message string) Event {
+	event.Message = message
+	return event
+}
+
+func (event Event) WithReplicaSet(replicaSet string) Event {
+	event.ReplicaSet = replicaSet
+	return event
+}
+
+func (event Event) WithPod(pod, container string) Event {
+	event.Pod = pod
+	event.Container = container
+	return event
+}
+
+func (event Event) WithStatus(status interface{}) Event {
+	event.Status = status
+	return event
+}
+
+// OutEvent prints the event as a json line
+func OutEvent(event Event) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	writeEvent(Out, event)
+}
+
+// OutEventF prints the event as a json line in the json output format and the message formatted
+// according to format otherwise. Nothing is printed in the text output format when format is empty.
+func OutEventF(event Event, format string, args ...interface{}) {
+	if IsJSONOutput() {
+		OutEvent(event)
+		return
+	}
+
+	if format != "" {
+		OutF(format, args...)
+	}
+}
+
+// OutputResourceLogLines prints log lines of the pod container, in the json output format every line
+// is a separate log event with Kind, Namespace, Name, Pod and Container of the event
+func OutputResourceLogLines(event Event, header string, logLines []LogLine) {
+	if !IsJSONOutput() {
+		OutputLogLines(header, logLines)
+		return
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	writeLogEvents(Out, event, logLines)
+}
+
+func writeEvent(out io.Writer, event Event) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		data, _ = json.Marshal(Event{
+			Type:      event.Type,
+			Kind:      event.Kind,
+			Namespace: event.Namespace,
+			Name:      event.Name,
+			Timestamp: event.Timestamp,
+			Message:   fmt.Sprintf("unable to marshal event: %s", err),
+		})
+	}
+
+	fmt.Fprintf(out, "%s\n", data)
+}
+
+func writeLogEvents(out io.Writer, event Event, logLines []LogLine) {
+	for _, line := range logLines {
+		lineEvent := event
+		lineEvent.Type = LogEvent
+		lineEvent.Message = line.Message
+		if timestamp, err := parseLogTimestamp(line.Timestamp); err == nil {
+			lineEvent.Timestamp = timestamp
+		}
+		writeEvent(out, lineEvent)
+	}
+}
@@ -0,0 +1,136 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Printer prints tracker output. Trackers use Printer from tracker.Options, so independent trackers
+// can print to separate outputs. All methods should be safe for concurrent use.
+type Printer interface {
+	// Message prints lifecycle message of the resource: added, ready, failed, event etc.
+	// Text is the human readable message without trailing newline.
+	Message(event Event, text string)
+	// LogLines prints log lines of the pod container of the resource,
+	// header is the human readable name of the container.
+	LogLines(event Event, header string, logLines []LogLine)
+	// ResourceStatus prints the status of the resource reported by the tracker feed.
+	ResourceStatus(event Event)
+	// StatusReport prints the human readable periodic status report of tracked resources.
+	StatusReport(text string)
+}
+
+// DefaultPrinter returns the printer which prints to the global Out writer
+// in the format set by SetOutputFormat and KUBEDOG_LOG_INLINE env var
+func DefaultPrinter() Printer {
+	return defaultPrinter{}
+}
+
+type defaultPrinter struct{}
+
+func (defaultPrinter) Message(event Event, text string) {
+	OutEventF(event, "%s\n", text)
+}
+
+func (defaultPrinter) LogLines(event Event, header string, logLines []LogLine) {
+	OutputResourceLogLines(event, header, logLines)
+}
+
+func (defaultPrinter) ResourceStatus(event Event) {
+	OutEventF(event, "")
+}
+
+func (defaultPrinter) StatusReport(text string) {
+	if !IsJSONOutput() {
+		OutF("%s", text)
+	}
+}
+
+// NewTextPrinter returns the printer of human readable text, log lines are grouped under container headers
+func NewTextPrinter(out io.Writer) Printer {
+	return NewTextPrinterWithOptions(out, TextPrinterOptions{})
+}
+
+// NewInlinePrinter returns the printer of human readable text, every log line is prefixed with container header
+func NewInlinePrinter(out io.Writer) Printer {
+	return NewTextPrinterWithOptions(out, TextPrinterOptions{Inline: true})
+}
+
+type TextPrinterOptions struct {
+	// Inline prefixes every log line with container header instead of grouping lines under headers
+	Inline bool
+	// LogTimestamps sets how timestamps of log lines are shown, timestamps are hidden by default
+	LogTimestamps LogTimestamps
+	// StructuredLogs sets how JSON log lines are shown, log lines are shown unchanged by default
+	StructuredLogs StructuredLogs
+}
+
+func NewTextPrinterWithOptions(out io.Writer, opts TextPrinterOptions) Printer {
+	return &textPrinter{
+		out:            out,
+		inline:         opts.Inline,
+		logTimestamps:  withLogTimestampsDefaults(opts.LogTimestamps),
+		structuredLogs: opts.StructuredLogs,
+	}
+}
+
+type textPrinter struct {
+	out            io.Writer
+	inline         bool
+	logTimestamps  LogTimestamps
+	structuredLogs StructuredLogs
+
+	mutex            sync.Mutex
+	currentLogHeader string
+}
+
+func (p *textPrinter) Message(event Event, text string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	fmt.Fprintf(p.out, "%s\n", text)
+}
+
+func (p *textPrinter) LogLines(event Event, header string, logLines []LogLine) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	writeLogLines(p.out, &p.currentLogHeader, p.inline, p.logTimestamps, p.structuredLogs, header, logLines)
+}
+
+func (p *textPrinter) ResourceStatus(event Event) {}
+
+func (p *textPrinter) StatusReport(text string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	fmt.Fprint(p.out, text)
+}
+
+// NewJSONPrinter returns the printer of newline delimited json events (NDJSON), human readable texts are omitted
+func NewJSONPrinter(out io.Writer) Printer {
+	return &jsonPrinter{out: out}
+}
+
+type jsonPrinter struct {
+	out   io.Writer
+	mutex sync.Mutex
+}
+
+func (p *jsonPrinter) Message(event Event, text string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	writeEvent(p.out, event)
+}
+
+func (p *jsonPrinter) LogLines(event Event, header string, logLines []LogLine) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	writeLogEvents(p.out, event, logLines)
+}
+
+func (p *jsonPrinter) ResourceStatus(event Event) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	writeEvent(p.out, event)
+}
+
+func (p *jsonPrinter) StatusReport(text string) {}
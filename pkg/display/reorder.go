@@ -0,0 +1,247 @@
+package display
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultLogsReorderLatency  = time.Second
+	DefaultLogsReorderMaxLines = 1000
+)
+
+type LogsReorderOptions struct {
+	// Latency is the max time a log line is held waiting for earlier lines of other pods and containers,
+	// DefaultLogsReorderLatency is used when not set
+	Latency time.Duration
+	// MaxLines limits the number of buffered log lines, the earliest lines are printed when the buffer is full,
+	// DefaultLogsReorderMaxLines is used when not set
+	MaxLines int
+	// FlushOnClose prints buffered log lines on Close, the lines are dropped otherwise
+	FlushOnClose bool
+}
+
+// LogsReorderPrinter is the Printer which merges log lines of all pods and containers in timestamps order
+// using bounded reorder buffer. Log lines are passed to the underlying printer with a delay up to Latency,
+// other output is passed right away after buffered log lines written before it. Close should be called when tracking is done.
+type LogsReorderPrinter struct {
+	printer Printer
+	opts    LogsReorderOptions
+
+	mutex          sync.Mutex
+	lines          []bufferedLogLine
+	lastTimes      map[string]time.Time
+	closed         bool
+	stopReleaseJob chan struct{}
+	releaseJobDone chan struct{}
+}
+
+type bufferedLogLine struct {
+	LogChunk
+	Time       time.Time
+	ReceivedAt time.Time
+}
+
+func NewLogsReorderPrinter(printer Printer, opts LogsReorderOptions) *LogsReorderPrinter {
+	if opts.Latency <= 0 {
+		opts.Latency = DefaultLogsReorderLatency
+	}
+	if opts.MaxLines <= 0 {
+		opts.MaxLines = DefaultLogsReorderMaxLines
+	}
+
+	p := &LogsReorderPrinter{
+		printer:        printer,
+		opts:           opts,
+		lastTimes:      make(map[string]time.Time),
+		stopReleaseJob: make(chan struct{}),
+		releaseJobDone: make(chan struct{}),
+	}
+
+	go p.runReleaseJob()
+
+	return p
+}
+
+func (p *LogsReorderPrinter) Message(event Event, text string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.releaseLinesUntil(eventTime(event))
+	p.printer.Message(event, text)
+}
+
+func (p *LogsReorderPrinter) ResourceStatus(event Event) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.releaseLinesUntil(eventTime(event))
+	p.printer.ResourceStatus(event)
+}
+
+func (p *LogsReorderPrinter) StatusReport(text string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.releaseLinesUntil(time.Now())
+	p.printer.StatusReport(text)
+}
+
+func (p *LogsReorderPrinter) LogLines(event Event, header string, logLines []LogLine) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.closed {
+		p.printer.LogLines(event, header, logLines)
+		return
+	}
+
+	now := time.Now()
+	containerKey := header + "\x00" + event.Namespace + "/" + event.Pod + "/" + event.Container
+
+	for _, line := range logLines {
+		// lines without timestamp follow the previous line of the container
+		lineTime, err := parseLogTimestamp(line.Timestamp)
+		if err != nil {
+			lineTime = p.lastTimes[containerKey]
+			if lineTime.IsZero() {
+				lineTime = now
+			}
+		}
+		p.lastTimes[containerKey] = lineTime
+
+		p.insertLine(bufferedLogLine{
+			LogChunk:   LogChunk{Event: event, Header: header, LogLines: []LogLine{line}},
+			Time:       lineTime,
+			ReceivedAt: now,
+		})
+	}
+
+	if excess := len(p.lines) - p.opts.MaxLines; excess > 0 {
+		p.releaseLines(excess)
+	}
+}
+
+// Flush prints all buffered log lines
+func (p *LogsReorderPrinter) Flush() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.releaseLines(len(p.lines))
+}
+
+// Close stops buffering, buffered log lines are printed when FlushOnClose is set.
+// Log lines received after Close are passed to the underlying printer right away.
+func (p *LogsReorderPrinter) Close() {
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		return
+	}
+	p.closed = true
+	p.mutex.Unlock()
+
+	close(p.stopReleaseJob)
+	<-p.releaseJobDone
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.opts.FlushOnClose {
+		p.releaseLines(len(p.lines))
+	} else {
+		p.lines = nil
+	}
+}
+
+func (p *LogsReorderPrinter) runReleaseJob() {
+	defer close(p.releaseJobDone)
+
+	interval := p.opts.Latency / 4
+	if interval < 10*time.Millisecond {
+		interval = 10 * time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.releaseExpiredLines()
+		case <-p.stopReleaseJob:
+			return
+		}
+	}
+}
+
+// releaseExpiredLines prints lines held longer than Latency together with all earlier buffered lines
+func (p *LogsReorderPrinter) releaseExpiredLines() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	expiredAt := time.Now().Add(-p.opts.Latency)
+
+	count := 0
+	for i, line := range p.lines {
+		if !line.ReceivedAt.After(expiredAt) {
+			count = i + 1
+		}
+	}
+
+	p.releaseLines(count)
+}
+
+// releaseLinesUntil prints buffered lines written not later than t, so that the output
+// following these lines is not printed before them
+func (p *LogsReorderPrinter) releaseLinesUntil(t time.Time) {
+	count := sort.Search(len(p.lines), func(i int) bool {
+		return p.lines[i].Time.After(t)
+	})
+
+	p.releaseLines(count)
+}
+
+// eventTime returns the event timestamp, events without timestamp happen now
+func eventTime(event Event) time.Time {
+	if event.Timestamp.IsZero() {
+		return time.Now()
+	}
+	return event.Timestamp
+}
+
+// insertLine inserts line into the buffer sorted by time, lines with equal time keep arrival order
+func (p *LogsReorderPrinter) insertLine(line bufferedLogLine) {
+	i := sort.Search(len(p.lines), func(i int) bool {
+		return p.lines[i].Time.After(line.Time)
+	})
+
+	p.lines = append(p.lines, bufferedLogLine{})
+	copy(p.lines[i+1:], p.lines[i:])
+	p.lines[i] = line
+}
+
+// releaseLines prints count earliest buffered lines, consecutive lines of the same container are printed together
+func (p *LogsReorderPrinter) releaseLines(count int) {
+	if count <= 0 {
+		return
+	}
+
+	chunks := []LogChunk{}
+	for _, line := range p.lines[:count] {
+		if len(chunks) > 0 {
+			last := &chunks[len(chunks)-1]
+			if last.Header == line.Header && isSameContainer(last.Event, line.Event) {
+				last.LogLines = append(last.LogLines, line.LogLines...)
+				continue
+			}
+		}
+		chunks = append(chunks, LogChunk{Event: line.Event, Header: line.Header, LogLines: append([]LogLine{}, line.LogLines...)})
+	}
+
+	p.lines = append(p.lines[:0], p.lines[count:]...)
+
+	for _, chunk := range chunks {
+		p.printer.LogLines(chunk.Event, chunk.Header, chunk.LogLines)
+	}
+}
@@ -0,0 +1,251 @@
+package display
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLogsReorderPrinterOrdering(t *testing.T) {
+	podA := Event{Kind: "deploy", Namespace: "myns", Name: "api", Pod: "api-a", Container: "main"}
+	podB := Event{Kind: "deploy", Namespace: "myns", Name: "api", Pod: "api-b", Container: "main"}
+
+	type input struct {
+		event Event
+		lines []LogLine
+	}
+
+	tests := []struct {
+		name     string
+		inputs   []input
+		expected []string
+	}{
+		{
+			name: "merged in timestamps order",
+			inputs: []input{
+				{podA, []LogLine{{"2019-01-01T00:00:03Z", "a3"}, {"2019-01-01T00:00:05Z", "a5"}}},
+				{podB, []LogLine{{"2019-01-01T00:00:01Z", "b1"}, {"2019-01-01T00:00:04Z", "b4"}}},
+			},
+			expected: []string{"api-b: b1", "api-a: a3", "api-b: b4", "api-a: a5"},
+		},
+		{
+			name: "consecutive lines of the container are grouped",
+			inputs: []input{
+				{podA, []LogLine{{"2019-01-01T00:00:01Z", "a1"}, {"2019-01-01T00:00:02Z", "a2"}}},
+				{podB, []LogLine{{"2019-01-01T00:00:03Z", "b3"}}},
+				{podA, []LogLine{{"2019-01-01T00:00:04Z", "a4"}}},
+			},
+			expected: []string{"api-a: a1 a2", "api-b: b3", "api-a: a4"},
+		},
+		{
+			name: "equal timestamps keep arrival order",
+			inputs: []input{
+				{podA, []LogLine{{"2019-01-01T00:00:01Z", "a1"}}},
+				{podB, []LogLine{{"2019-01-01T00:00:01Z", "b1"}}},
+				{podA, []LogLine{{"2019-01-01T00:00:01Z", "a1'"}}},
+			},
+			expected: []string{"api-a: a1", "api-b: b1", "api-a: a1'"},
+		},
+		{
+			name: "lines without timestamp follow the previous line of the container",
+			inputs: []input{
+				{podA, []LogLine{{"2019-01-01T00:00:01Z", "a1"}, {"", "a1-continued"}}},
+				{podB, []LogLine{{"2019-01-01T00:00:02Z", "b2"}}},
+				{podA, []LogLine{{"bad timestamp", "a1-more"}, {"2019-01-01T00:00:03Z", "a3"}}},
+			},
+			expected: []string{"api-a: a1 a1-continued a1-more", "api-b: b2", "api-a: a3"},
+		},
+		{
+			name: "nanoseconds and time zones",
+			inputs: []input{
+				{podA, []LogLine{{"2019-01-01T03:00:00.000000002+03:00", "a2"}}},
+				{podB, []LogLine{{"2019-01-01T00:00:00.000000001Z", "b1"}}},
+			},
+			expected: []string{"api-b: b1", "api-a: a2"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out := &recordingPrinter{}
+			printer := NewLogsReorderPrinter(out, LogsReorderOptions{Latency: time.Hour})
+			defer printer.Close()
+
+			for _, input := range test.inputs {
+				printer.LogLines(input.event, "po/"+input.event.Pod, input.lines)
+			}
+
+			if chunks := out.logChunks(); len(chunks) != 0 {
+				t.Fatalf("expected log lines to be buffered, got %v", chunks)
+			}
+
+			printer.Flush()
+
+			if chunks := out.logChunks(); !reflect.DeepEqual(chunks, test.expected) {
+				t.Errorf("expected %q, got %q", test.expected, chunks)
+			}
+		})
+	}
+}
+
+func TestLogsReorderPrinterMaxLines(t *testing.T) {
+	event := Event{Kind: "po", Namespace: "myns", Name: "api", Pod: "api", Container: "main"}
+
+	out := &recordingPrinter{}
+	printer := NewLogsReorderPrinter(out, LogsReorderOptions{Latency: time.Hour, MaxLines: 2})
+	defer printer.Close()
+
+	printer.LogLines(event, "po/api", []LogLine{{"2019-01-01T00:00:03Z", "3"}, {"2019-01-01T00:00:01Z", "1"}})
+	if chunks := out.logChunks(); len(chunks) != 0 {
+		t.Fatalf("expected log lines to be buffered, got %q", chunks)
+	}
+
+	printer.LogLines(event, "po/api", []LogLine{{"2019-01-01T00:00:02Z", "2"}, {"2019-01-01T00:00:04Z", "4"}})
+	if expected, chunks := []string{"api: 1 2"}, out.logChunks(); !reflect.DeepEqual(chunks, expected) {
+		t.Fatalf("expected earliest lines %q to be released, got %q", expected, chunks)
+	}
+}
+
+func TestLogsReorderPrinterLatency(t *testing.T) {
+	event := Event{Kind: "po", Namespace: "myns", Name: "api", Pod: "api", Container: "main"}
+
+	out := &recordingPrinter{}
+	printer := NewLogsReorderPrinter(out, LogsReorderOptions{Latency: 50 * time.Millisecond})
+	defer printer.Close()
+
+	printer.LogLines(event, "po/api", []LogLine{{"2019-01-01T00:00:01Z", "1"}})
+
+	deadline := time.Now().Add(10 * time.Second)
+	for len(out.logChunks()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("log line has not been released after the latency")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if expected, chunks := []string{"api: 1"}, out.logChunks(); !reflect.DeepEqual(chunks, expected) {
+		t.Errorf("expected %q, got %q", expected, chunks)
+	}
+}
+
+func TestLogsReorderPrinterClose(t *testing.T) {
+	event := Event{Kind: "po", Namespace: "myns", Name: "api", Pod: "api", Container: "main"}
+
+	for _, test := range []struct {
+		name         string
+		flushOnClose bool
+		expected     []string
+	}{
+		{name: "flush on close", flushOnClose: true, expected: []string{"api: 1", "api: 2"}},
+		{name: "drop on close", expected: []string{"api: 2"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := &recordingPrinter{}
+			printer := NewLogsReorderPrinter(out, LogsReorderOptions{Latency: time.Hour, FlushOnClose: test.flushOnClose})
+
+			printer.Message(event.WithType(ReadyEvent), "# po/api become READY")
+			if messages := out.messages(); len(messages) != 1 {
+				t.Errorf("expected message to be printed right away, got %q", messages)
+			}
+			printer.LogLines(event, "po/api", []LogLine{{"2019-01-01T00:00:01Z", "1"}})
+
+			printer.Close()
+			printer.Close()
+
+			// lines are passed right away after close
+			printer.LogLines(event, "po/api", []LogLine{{"2019-01-01T00:00:02Z", "2"}})
+
+			if chunks := out.logChunks(); !reflect.DeepEqual(chunks, test.expected) {
+				t.Errorf("expected %q, got %q", test.expected, chunks)
+			}
+		})
+	}
+}
+
+func TestLogsReorderPrinterMessages(t *testing.T) {
+	podA := Event{Kind: "deploy", Namespace: "myns", Name: "api", Pod: "api-a", Container: "main"}
+	podB := Event{Kind: "deploy", Namespace: "myns", Name: "api", Pod: "api-b", Container: "main"}
+	future := time.Now().Add(time.Hour).Format(time.RFC3339Nano)
+
+	out := &recordingPrinter{}
+	printer := NewLogsReorderPrinter(out, LogsReorderOptions{Latency: time.Hour})
+	defer printer.Close()
+
+	printer.LogLines(podA, "po/api-a", []LogLine{{"2019-01-01T00:00:03Z", "a3"}, {future, "a-future"}})
+	printer.LogLines(podB, "po/api-b", []LogLine{{"2019-01-01T00:00:01Z", "b1"}})
+
+	withTimestamp := podA.WithType(EventMsgEvent)
+	withTimestamp.Timestamp = time.Date(2019, 1, 1, 0, 0, 2, 0, time.UTC)
+	printer.Message(withTimestamp, "# event at 00:00:02")
+
+	printer.LogLines(podB, "po/api-b", []LogLine{{"2019-01-01T00:00:04Z", "b4"}})
+	printer.Message(podA.WithType(FailedEvent), "# deploy/api FAILED")
+
+	expected := []string{
+		"api-b: b1",
+		"# event at 00:00:02",
+		"api-a: a3",
+		"api-b: b4",
+		"# deploy/api FAILED",
+	}
+	if output := out.output(); !reflect.DeepEqual(output, expected) {
+		t.Errorf("expected %q, got %q", expected, output)
+	}
+
+	printer.Flush()
+	if expected, chunks := "api-a: a-future", out.logChunks(); chunks[len(chunks)-1] != expected {
+		t.Errorf("expected line with later timestamp %q to be printed on flush, got %q", expected, chunks)
+	}
+}
+
+// recordingPrinter records printed log chunks as "pod: messages", messages and the order of both
+type recordingPrinter struct {
+	mux          sync.Mutex
+	chunks       []string
+	messageTexts []string
+	printed      []string
+}
+
+func (p *recordingPrinter) Message(event Event, text string) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.messageTexts = append(p.messageTexts, text)
+	p.printed = append(p.printed, text)
+}
+
+func (p *recordingPrinter) LogLines(event Event, header string, logLines []LogLine) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	messages := []string{}
+	for _, line := range logLines {
+		messages = append(messages, line.Message)
+	}
+	chunk := event.Pod + ": " + strings.Join(messages, " ")
+	p.chunks = append(p.chunks, chunk)
+	p.printed = append(p.printed, chunk)
+}
+
+func (p *recordingPrinter) ResourceStatus(event Event) {}
+
+func (p *recordingPrinter) StatusReport(text string) {}
+
+func (p *recordingPrinter) logChunks() []string {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return append([]string{}, p.chunks...)
+}
+
+func (p *recordingPrinter) messages() []string {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return append([]string{}, p.messageTexts...)
+}
+
+func (p *recordingPrinter) output() []string {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return append([]string{}, p.printed...)
+}
@@ -0,0 +1,249 @@
+package display
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+type LogLevel int
+
+const (
+	// UnknownLogLevel is the level of log lines without recognized level
+	UnknownLogLevel LogLevel = iota
+	TraceLogLevel
+	DebugLogLevel
+	InfoLogLevel
+	WarnLogLevel
+	ErrorLogLevel
+	FatalLogLevel
+)
+
+func (level LogLevel) String() string {
+	switch level {
+	case TraceLogLevel:
+		return "trace"
+	case DebugLogLevel:
+		return "debug"
+	case InfoLogLevel:
+		return "info"
+	case WarnLogLevel:
+		return "warn"
+	case ErrorLogLevel:
+		return "error"
+	case FatalLogLevel:
+		return "fatal"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseLogLevel parses level name: trace, debug, info, warn, error or fatal.
+// Common aliases like warning, err, critical or panic are accepted, case is ignored.
+func ParseLogLevel(name string) (LogLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "trace":
+		return TraceLogLevel, nil
+	case "debug", "dbg":
+		return DebugLogLevel, nil
+	case "info", "information", "notice":
+		return InfoLogLevel, nil
+	case "warn", "warning":
+		return WarnLogLevel, nil
+	case "error", "err":
+		return ErrorLogLevel, nil
+	case "fatal", "critical", "crit", "panic", "dpanic", "alert", "emerg", "emergency":
+		return FatalLogLevel, nil
+	default:
+		return UnknownLogLevel, fmt.Errorf("unsupported log level %q, expected one of: trace, debug, info, warn, error, fatal", name)
+	}
+}
+
+var (
+	structuredLogLevelKeys   = []string{"level", "lvl", "severity", "log.level", "loglevel"}
+	structuredLogMessageKeys = []string{"msg", "message"}
+)
+
+// StructuredLogLine is the JSON log line message split into level, message and other fields
+type StructuredLogLine struct {
+	Level LogLevel
+	// LevelName is the level as written in the log line, it is set for unknown levels too
+	LevelName string
+	Message   string
+	Fields    map[string]interface{}
+}
+
+// ParseStructuredLogLine parses the log line message which is a JSON object.
+// Level is taken from level, lvl, severity, log.level or loglevel field (names or numeric bunyan/pino levels),
+// message is taken from msg or message field. Returns false when the message is not a JSON object.
+func ParseStructuredLogLine(message string) (StructuredLogLine, bool) {
+	data := strings.TrimSpace(message)
+	if !strings.HasPrefix(data, "{") || !strings.HasSuffix(data, "}") {
+		return StructuredLogLine{}, false
+	}
+
+	fields := make(map[string]interface{})
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return StructuredLogLine{}, false
+	}
+	// text after the JSON object would be lost on rendering
+	if _, err := decoder.Token(); err != io.EOF {
+		return StructuredLogLine{}, false
+	}
+
+	line := StructuredLogLine{Fields: fields}
+
+	for _, key := range structuredLogLevelKeys {
+		value, hasKey := fields[key]
+		if !hasKey {
+			continue
+		}
+
+		switch v := value.(type) {
+		case string:
+			line.LevelName = v
+			line.Level, _ = ParseLogLevel(v)
+		case json.Number:
+			line.LevelName = v.String()
+			line.Level = numericLogLevel(v)
+		}
+		break
+	}
+
+	for _, key := range structuredLogMessageKeys {
+		if value, ok := fields[key].(string); ok {
+			line.Message = value
+			break
+		}
+	}
+
+	return line, true
+}
+
+// numericLogLevel converts bunyan and pino numeric levels: 10 trace, 20 debug, 30 info, 40 warn, 50 error, 60 fatal
+func numericLogLevel(number json.Number) LogLevel {
+	value, err := number.Int64()
+	if err != nil {
+		return UnknownLogLevel
+	}
+
+	switch {
+	case value >= 60:
+		return FatalLogLevel
+	case value >= 50:
+		return ErrorLogLevel
+	case value >= 40:
+		return WarnLogLevel
+	case value >= 30:
+		return InfoLogLevel
+	case value >= 20:
+		return DebugLogLevel
+	case value >= 10:
+		return TraceLogLevel
+	default:
+		return UnknownLogLevel
+	}
+}
+
+// LogLineLevel returns the level of the JSON log line message, UnknownLogLevel is returned for other messages
+func LogLineLevel(message string) LogLevel {
+	line, ok := ParseStructuredLogLine(message)
+	if !ok {
+		return UnknownLogLevel
+	}
+	return line.Level
+}
+
+// StructuredLogs configures how JSON log lines are shown by text printers
+type StructuredLogs struct {
+	// Enabled shows JSON log lines compactly as colored level, message and Fields,
+	// other log lines are shown unchanged
+	Enabled bool
+	// Fields are names of JSON log line fields shown after the message as name=value,
+	// other fields are hidden
+	Fields []string
+}
+
+var structuredLogs StructuredLogs
+
+// SetStructuredLogs sets how JSON log lines are shown by the default printer
+func SetStructuredLogs(structured StructuredLogs) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	structuredLogs = structured
+}
+
+// formatStructuredLogLine returns the log line with compactly rendered JSON message when structured logs are enabled
+func formatStructuredLogLine(structured StructuredLogs, line LogLine) LogLine {
+	if !structured.Enabled {
+		return line
+	}
+
+	structuredLine, ok := ParseStructuredLogLine(line.Message)
+	if !ok {
+		return line
+	}
+
+	parts := []string{}
+	if structuredLine.LevelName != "" {
+		levelName := fmt.Sprintf("%-5s", strings.ToUpper(structuredLine.LevelName))
+		if levelColor := logLevelColor(structuredLine.Level); levelColor != nil {
+			levelName = levelColor.Sprint(levelName)
+		}
+		parts = append(parts, levelName)
+	}
+	if structuredLine.Message != "" {
+		parts = append(parts, structuredLine.Message)
+	}
+	for _, name := range structured.Fields {
+		if value, hasKey := structuredLine.Fields[name]; hasKey {
+			parts = append(parts, fmt.Sprintf("%s=%s", name, formatStructuredLogField(value)))
+		}
+	}
+
+	// nothing recognized, show the line as is
+	if len(parts) == 0 {
+		return line
+	}
+
+	line.Message = strings.Join(parts, " ")
+	return line
+}
+
+func formatStructuredLogField(value interface{}) string {
+	if s, ok := value.(string); ok && s != "" && !strings.ContainsAny(s, " \t\"=") {
+		return s
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func logLevelColor(level LogLevel) *color.Color {
+	switch level {
+	case TraceLogLevel, DebugLogLevel:
+		return color.New(color.FgHiBlack)
+	case InfoLogLevel:
+		return color.New(color.FgCyan)
+	case WarnLogLevel:
+		return color.New(color.FgYellow)
+	case ErrorLogLevel:
+		return color.New(color.FgRed)
+	case FatalLogLevel:
+		return color.New(color.FgRed, color.Bold)
+	default:
+		return nil
+	}
+}
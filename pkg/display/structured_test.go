@@ -0,0 +1,225 @@
+package display
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestParseStructuredLogLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		ok       bool
+		expected StructuredLogLine
+	}{
+		{
+			name:     "level and msg",
+			message:  `{"level":"warn","msg":"slow query","duration":"2s"}`,
+			ok:       true,
+			expected: StructuredLogLine{Level: WarnLogLevel, LevelName: "warn", Message: "slow query"},
+		},
+		{
+			name:     "severity and message with spaces around",
+			message:  "  {\"severity\": \"ERROR\", \"message\": \"connection refused\"}\n",
+			ok:       true,
+			expected: StructuredLogLine{Level: ErrorLogLevel, LevelName: "ERROR", Message: "connection refused"},
+		},
+		{
+			name:     "aliases",
+			message:  `{"lvl":"warning","msg":"disk usage 91%"}`,
+			ok:       true,
+			expected: StructuredLogLine{Level: WarnLogLevel, LevelName: "warning", Message: "disk usage 91%"},
+		},
+		{
+			name:     "dotted level key",
+			message:  `{"log.level":"critical","message":"out of memory"}`,
+			ok:       true,
+			expected: StructuredLogLine{Level: FatalLogLevel, LevelName: "critical", Message: "out of memory"},
+		},
+		{
+			name:     "first level key wins",
+			message:  `{"severity":"debug","level":"info","msg":"started"}`,
+			ok:       true,
+			expected: StructuredLogLine{Level: InfoLogLevel, LevelName: "info", Message: "started"},
+		},
+		{
+			name:     "numeric bunyan level",
+			message:  `{"level":50,"msg":"request failed"}`,
+			ok:       true,
+			expected: StructuredLogLine{Level: ErrorLogLevel, LevelName: "50", Message: "request failed"},
+		},
+		{
+			name:     "numeric pino trace level",
+			message:  `{"level":10,"msg":"enter"}`,
+			ok:       true,
+			expected: StructuredLogLine{Level: TraceLogLevel, LevelName: "10", Message: "enter"},
+		},
+		{
+			name:     "numeric level below trace",
+			message:  `{"level":5}`,
+			ok:       true,
+			expected: StructuredLogLine{Level: UnknownLogLevel, LevelName: "5"},
+		},
+		{
+			name:     "fractional numeric level",
+			message:  `{"level":30.5}`,
+			ok:       true,
+			expected: StructuredLogLine{Level: UnknownLogLevel, LevelName: "30.5"},
+		},
+		{
+			name:     "unknown level name",
+			message:  `{"level":"verbose","msg":"tick"}`,
+			ok:       true,
+			expected: StructuredLogLine{Level: UnknownLogLevel, LevelName: "verbose", Message: "tick"},
+		},
+		{
+			name:     "not string message",
+			message:  `{"msg":{"text":"nested"},"message":"flat"}`,
+			ok:       true,
+			expected: StructuredLogLine{Message: "flat"},
+		},
+		{
+			name:     "no level and message",
+			message:  `{"ts":1546300800}`,
+			ok:       true,
+			expected: StructuredLogLine{},
+		},
+		{name: "plain text", message: "2019-01-01 ERROR connection refused"},
+		{name: "array", message: `["level","error"]`},
+		{name: "broken json", message: `{"level":"error","msg":}`},
+		{name: "trailing text", message: `{"level":"error"} and more}`},
+		{name: "empty", message: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			line, ok := ParseStructuredLogLine(test.message)
+			if ok != test.ok {
+				t.Fatalf("expected ok %v, got %v", test.ok, ok)
+			}
+			if !ok {
+				return
+			}
+
+			if line.Level != test.expected.Level || line.LevelName != test.expected.LevelName || line.Message != test.expected.Message {
+				t.Errorf("expected level %s (%q) and message %q, got level %s (%q) and message %q",
+					test.expected.Level, test.expected.LevelName, test.expected.Message, line.Level, line.LevelName, line.Message)
+			}
+			if line.Fields == nil {
+				t.Errorf("expected fields to be set")
+			}
+		})
+	}
+}
+
+func TestParseStructuredLogLineFields(t *testing.T) {
+	line, ok := ParseStructuredLogLine(`{"level":"info","msg":"done","status":200,"user":{"id":"42"}}`)
+	if !ok {
+		t.Fatal("expected JSON log line")
+	}
+
+	expected := map[string]interface{}{
+		"level":  "info",
+		"msg":    "done",
+		"status": json.Number("200"),
+		"user":   map[string]interface{}{"id": "42"},
+	}
+	if !reflect.DeepEqual(line.Fields, expected) {
+		t.Errorf("expected fields %v, got %v", expected, line.Fields)
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected LogLevel
+		err      bool
+	}{
+		{name: "trace", expected: TraceLogLevel},
+		{name: "DBG", expected: DebugLogLevel},
+		{name: " notice ", expected: InfoLogLevel},
+		{name: "Warning", expected: WarnLogLevel},
+		{name: "err", expected: ErrorLogLevel},
+		{name: "panic", expected: FatalLogLevel},
+		{name: "emerg", expected: FatalLogLevel},
+		{name: "verbose", err: true},
+		{name: "", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			level, err := ParseLogLevel(test.name)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+			if level != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, level)
+			}
+		})
+	}
+}
+
+func TestFormatStructuredLogLine(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = noColor }()
+
+	tests := []struct {
+		name       string
+		structured StructuredLogs
+		message    string
+		expected   string
+	}{
+		{
+			name:       "disabled",
+			structured: StructuredLogs{Fields: []string{"status"}},
+			message:    `{"level":"info","msg":"done"}`,
+			expected:   `{"level":"info","msg":"done"}`,
+		},
+		{
+			name:       "level and message",
+			structured: StructuredLogs{Enabled: true},
+			message:    `{"level":"info","msg":"done","status":200}`,
+			expected:   "INFO  done",
+		},
+		{
+			name:       "selected fields",
+			structured: StructuredLogs{Enabled: true, Fields: []string{"status", "path", "error", "missing", "user"}},
+			message:    `{"level":"error","msg":"request failed","status":500,"path":"/api","error":"no such host \"db\"","user":{"id":"42"}}`,
+			expected:   `ERROR request failed status=500 path=/api error="no such host \"db\"" user={"id":"42"}`,
+		},
+		{
+			name:       "long level name",
+			structured: StructuredLogs{Enabled: true},
+			message:    `{"severity":"warning"}`,
+			expected:   "WARNING",
+		},
+		{
+			name:       "nothing recognized",
+			structured: StructuredLogs{Enabled: true},
+			message:    `{"ts":1546300800}`,
+			expected:   `{"ts":1546300800}`,
+		},
+		{
+			name:       "plain text",
+			structured: StructuredLogs{Enabled: true},
+			message:    "connection refused",
+			expected:   "connection refused",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			line := formatStructuredLogLine(test.structured, LogLine{Timestamp: "2019-01-01T00:00:00Z", Message: test.message})
+			if line.Message != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, line.Message)
+			}
+			if line.Timestamp != "2019-01-01T00:00:00Z" {
+				t.Errorf("expected timestamp to be kept, got %q", line.Timestamp)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package display
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+type LogTimestampsMode string
+
+const (
+	// NoLogTimestamps hides timestamps of log lines (default)
+	NoLogTimestamps LogTimestampsMode = ""
+	// UTCLogTimestamps shows timestamps of log lines in UTC
+	UTCLogTimestamps LogTimestampsMode = "utc"
+	// LocalLogTimestamps shows timestamps of log lines in the local time zone
+	LocalLogTimestamps LogTimestampsMode = "local"
+	// RelativeLogTimestamps shows timestamps of log lines as a duration since Since time
+	RelativeLogTimestamps LogTimestampsMode = "relative"
+)
+
+// ParseLogTimestampsMode parses mode name: none, utc, local or relative
+func ParseLogTimestampsMode(mode string) (LogTimestampsMode, error) {
+	switch mode {
+	case "none", "":
+		return NoLogTimestamps, nil
+	case string(UTCLogTimestamps), string(LocalLogTimestamps), string(RelativeLogTimestamps):
+		return LogTimestampsMode(mode), nil
+	default:
+		return "", fmt.Errorf("unsupported log timestamps mode %q, expected one of: none, utc, local, relative", mode)
+	}
+}
+
+const DefaultLogTimestampsFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// LogTimestamps configures how timestamps of log lines are shown by text printers
+type LogTimestamps struct {
+	Mode LogTimestampsMode
+	// Format is the time layout for utc and local modes, DefaultLogTimestampsFormat is used when empty
+	Format string
+	// Since is the start of tracking for relative mode, the time when options are set is used when empty
+	Since time.Time
+}
+
+var logTimestamps LogTimestamps
+
+// SetLogTimestamps sets how timestamps of log lines are shown by the default printer
+func SetLogTimestamps(timestamps LogTimestamps) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	logTimestamps = withLogTimestampsDefaults(timestamps)
+}
+
+func withLogTimestampsDefaults(timestamps LogTimestamps) LogTimestamps {
+	if timestamps.Format == "" {
+		timestamps.Format = DefaultLogTimestampsFormat
+	}
+	if timestamps.Mode == RelativeLogTimestamps && timestamps.Since.IsZero() {
+		timestamps.Since = time.Now()
+	}
+	return timestamps
+}
+
+// formatLogLine returns the message of log line prefixed with formatted timestamp according to timestamps mode
+func formatLogLine(timestamps LogTimestamps, line LogLine) string {
+	if timestamps.Mode == NoLogTimestamps {
+		return line.Message
+	}
+
+	timestamp, err := parseLogTimestamp(line.Timestamp)
+	if err != nil {
+		return line.Message
+	}
+
+	var formatted string
+	switch timestamps.Mode {
+	case UTCLogTimestamps:
+		formatted = timestamp.UTC().Format(timestamps.Format)
+	case LocalLogTimestamps:
+		formatted = timestamp.Local().Format(timestamps.Format)
+	case RelativeLogTimestamps:
+		since := timestamp.Sub(timestamps.Since).Truncate(time.Millisecond)
+		if since >= 0 {
+			formatted = "+" + since.String()
+		} else {
+			formatted = since.String()
+		}
+	default:
+		return line.Message
+	}
+
+	return formatted + " " + line.Message
+}
+
+func parseLogTimestamp(timestamp string) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, timestamp)
+}
+
+// LogChunk is log lines of the pod container of the resource
+type LogChunk struct {
+	Event    Event
+	Header   string
+	LogLines []LogLine
+}
+
+// OrderLogChunks merges log lines of chunks from multiple containers in timestamps order.
+// Order of lines of the same container is kept, lines without timestamp follow the previous line of their chunk.
+// Consecutive lines of the same container are grouped into one chunk.
+func OrderLogChunks(chunks []LogChunk) []LogChunk {
+	type orderedLine struct {
+		Time  time.Time
+		Chunk int
+		Line  LogLine
+	}
+
+	lines := []orderedLine{}
+	for i, chunk := range chunks {
+		var lastTime time.Time
+		for _, line := range chunk.LogLines {
+			if timestamp, err := parseLogTimestamp(line.Timestamp); err == nil {
+				lastTime = timestamp
+			}
+			lines = append(lines, orderedLine{Time: lastTime, Chunk: i, Line: line})
+		}
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Time.Before(lines[j].Time)
+	})
+
+	res := []LogChunk{}
+	for _, line := range lines {
+		chunk := chunks[line.Chunk]
+		if len(res) > 0 {
+			last := &res[len(res)-1]
+			if last.Header == chunk.Header && isSameContainer(last.Event, chunk.Event) {
+				last.LogLines = append(last.LogLines, line.Line)
+				continue
+			}
+		}
+		res = append(res, LogChunk{Event: chunk.Event, Header: chunk.Header, LogLines: []LogLine{line.Line}})
+	}
+
+	return res
+}
+
+func isSameContainer(a, b Event) bool {
+	return a.Kind == b.Kind && a.Namespace == b.Namespace && a.Name == b.Name && a.Pod == b.Pod && a.Container == b.Container
+}
@@ -11,6 +11,12 @@ type ControllerFeed interface {
 	OnAddedPod(func(replicaset.ReplicaSetPod) error)
 	OnPodLogChunk(func(*replicaset.ReplicaSetPodLogChunk) error)
 	OnPodError(func(replicaset.ReplicaSetPodError) error)
+	// OnPodContainerTermination is called once for every crashed container instance with its termination details and last log lines
+	OnPodContainerTermination(func(replicaset.ReplicaSetPodContainerTermination) error)
+	// OnPodUnschedulable is called when the pod cannot be scheduled and when the scheduling failure reasons change
+	OnPodUnschedulable(func(replicaset.ReplicaSetPodSchedulingFailure) error)
+	// OnPodContainerRestart is called when the container restart count grows since the start of tracking
+	OnPodContainerRestart(func(replicaset.ReplicaSetPodContainerRestart) error)
 }
 
 type CommonControllerFeed struct {
@@ -22,6 +28,10 @@ type CommonControllerFeed struct {
 	OnAddedPodFunc        func(replicaset.ReplicaSetPod) error
 	OnPodLogChunkFunc     func(*replicaset.ReplicaSetPodLogChunk) error
 	OnPodErrorFunc        func(replicaset.ReplicaSetPodError) error
+
+	OnPodContainerTerminationFunc func(replicaset.ReplicaSetPodContainerTermination) error
+	OnPodUnschedulableFunc        func(replicaset.ReplicaSetPodSchedulingFailure) error
+	OnPodContainerRestartFunc     func(replicaset.ReplicaSetPodContainerRestart) error
 }
 
 func (f *CommonControllerFeed) OnAdded(function func(bool) error) {
@@ -48,3 +58,12 @@ func (f *CommonControllerFeed) OnPodLogChunk(function func(*replicaset.ReplicaSe
 func (f *CommonControllerFeed) OnPodError(function func(replicaset.ReplicaSetPodError) error) {
 	f.OnPodErrorFunc = function
 }
+func (f *CommonControllerFeed) OnPodContainerTermination(function func(replicaset.ReplicaSetPodContainerTermination) error) {
+	f.OnPodContainerTerminationFunc = function
+}
+func (f *CommonControllerFeed) OnPodUnschedulable(function func(replicaset.ReplicaSetPodSchedulingFailure) error) {
+	f.OnPodUnschedulableFunc = function
+}
+func (f *CommonControllerFeed) OnPodContainerRestart(function func(replicaset.ReplicaSetPodContainerRestart) error) {
+	f.OnPodContainerRestartFunc = function
+}
@@ -38,8 +38,8 @@ func (f *feed) OnStatusReport(function func(DaemonSetStatus) error) {
 }
 
 func (f *feed) Track(name, namespace string, kube kubernetes.Interface, opts tracker.Options) error {
-	errorChan := make(chan error, 0)
-	doneChan := make(chan bool, 0)
+	errorChan := make(chan error, 1)
+	doneChan := make(chan bool, 1)
 
 	parentContext := opts.ParentContext
 	if parentContext == nil {
@@ -183,6 +183,51 @@ func (f *feed) Track(name, namespace string, kube kubernetes.Interface, opts tra
 				}
 			}
 
+		case termination := <-daemonSetTracker.PodContainerTermination:
+			if debug.Debug() {
+				fmt.Printf("    ds/%s po/%s container/%s terminated: %s\n", daemonSetTracker.ResourceName, termination.PodName, termination.ContainerName, termination.Summary())
+			}
+
+			if f.OnPodContainerTerminationFunc != nil {
+				err := f.OnPodContainerTerminationFunc(termination)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case failure := <-daemonSetTracker.PodUnschedulable:
+			if debug.Debug() {
+				fmt.Printf("    ds/%s po/%s unschedulable: %s\n", daemonSetTracker.ResourceName, failure.PodName, failure.Message)
+			}
+
+			if f.OnPodUnschedulableFunc != nil {
+				err := f.OnPodUnschedulableFunc(failure)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case restart := <-daemonSetTracker.PodContainerRestart:
+			if debug.Debug() {
+				fmt.Printf("    ds/%s po/%s container/%s restarted: %s\n", daemonSetTracker.ResourceName, restart.PodName, restart.ContainerName, restart.Summary())
+			}
+
+			if f.OnPodContainerRestartFunc != nil {
+				err := f.OnPodContainerRestartFunc(restart)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
 		case status := <-daemonSetTracker.StatusReport:
 			f.setStatus(status)
 
@@ -26,6 +26,9 @@ import (
 type DaemonSetStatus struct {
 	extensions.DaemonSetStatus
 	Pods map[string]pod.PodStatus
+
+	// Annotations are annotations of the tracked object
+	Annotations map[string]string
 }
 
 func NewDaemonSetStatus(kubeStatus extensions.DaemonSetStatus, podsStatuses map[string]pod.PodStatus) DaemonSetStatus {
@@ -39,9 +42,16 @@ func NewDaemonSetStatus(kubeStatus extensions.DaemonSetStatus, podsStatuses map[
 	return res
 }
 
+func (d *Tracker) newDaemonSetStatus() DaemonSetStatus {
+	status := NewDaemonSetStatus(d.lastObject.Status, d.podStatuses)
+	status.Annotations = d.lastObject.Annotations
+	return status
+}
+
 type Tracker struct {
 	tracker.Tracker
-	LogsFromTime time.Time
+	LogsFromTime          time.Time
+	FatalContainerReasons []string
 
 	State                string
 	Conditions           []string
@@ -58,6 +68,10 @@ type Tracker struct {
 	PodError     chan replicaset.ReplicaSetPodError
 	StatusReport chan DaemonSetStatus
 
+	PodContainerTermination chan replicaset.ReplicaSetPodContainerTermination
+	PodUnschedulable        chan replicaset.ReplicaSetPodSchedulingFailure
+	PodContainerRestart     chan replicaset.ReplicaSetPodContainerRestart
+
 	resourceAdded     chan *extensions.DaemonSet
 	resourceModified  chan *extensions.DaemonSet
 	resourceDeleted   chan *extensions.DaemonSet
@@ -83,7 +97,8 @@ func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Int
 			Context:          ctx,
 		},
 
-		LogsFromTime: opts.LogsFromTime,
+		LogsFromTime:          opts.LogsFromTime,
+		FatalContainerReasons: opts.FatalContainerReasons,
 
 		Added:        make(chan bool, 0),
 		Ready:        make(chan bool, 1),
@@ -94,6 +109,10 @@ func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Int
 		PodError:     make(chan replicaset.ReplicaSetPodError, 0),
 		StatusReport: make(chan DaemonSetStatus, 100),
 
+		PodContainerTermination: make(chan replicaset.ReplicaSetPodContainerTermination, 0),
+		PodUnschedulable:        make(chan replicaset.ReplicaSetPodSchedulingFailure, 0),
+		PodContainerRestart:     make(chan replicaset.ReplicaSetPodContainerRestart, 0),
+
 		podStatuses: make(map[string]pod.PodStatus),
 		TrackedPods: make([]string, 0),
 
@@ -126,7 +145,10 @@ func (d *Tracker) Track() error {
 		select {
 		case object := <-d.resourceAdded:
 			d.lastObject = object
-			d.StatusReport <- NewDaemonSetStatus(d.lastObject.Status, d.podStatuses)
+			select {
+			case d.StatusReport <- d.newDaemonSetStatus():
+			case <-d.Context.Done():
+			}
 
 			ready, err := d.handleDaemonSetStatus(object)
 			if err != nil {
@@ -142,7 +164,10 @@ func (d *Tracker) Track() error {
 			switch d.State {
 			case "":
 				d.State = "Started"
-				d.Added <- ready
+				select {
+				case d.Added <- ready:
+				case <-d.Context.Done():
+				}
 			}
 
 			d.runPodsInformer()
@@ -154,22 +179,37 @@ func (d *Tracker) Track() error {
 				return err
 			}
 			d.lastObject = object
-			d.StatusReport <- NewDaemonSetStatus(d.lastObject.Status, d.podStatuses)
+			select {
+			case d.StatusReport <- d.newDaemonSetStatus():
+			case <-d.Context.Done():
+			}
 			if ready {
-				d.Ready <- true
+				select {
+				case d.Ready <- true:
+				case <-d.Context.Done():
+				}
 			}
 
 		case <-d.resourceDeleted:
 			d.lastObject = nil
-			d.StatusReport <- DaemonSetStatus{}
+			select {
+			case d.StatusReport <- DaemonSetStatus{}:
+			case <-d.Context.Done():
+			}
 
 			d.State = "Deleted"
-			d.Failed <- "resource deleted"
+			select {
+			case d.Failed <- "resource deleted":
+			case <-d.Context.Done():
+			}
 			// FIXME: this is not fail
 
 		case reason := <-d.resourceFailed:
 			d.State = "Failed"
-			d.Failed <- reason
+			select {
+			case d.Failed <- reason:
+			case <-d.Context.Done():
+			}
 
 		case pod := <-d.podAdded:
 			if debug.Debug() {
@@ -181,7 +221,10 @@ func (d *Tracker) Track() error {
 				ReplicaSet: replicaset.ReplicaSet{},
 			}
 
-			d.AddedPod <- rsPod
+			select {
+			case d.AddedPod <- rsPod:
+			case <-d.Context.Done():
+			}
 
 			err := d.runPodTracker(pod.Name)
 			if err != nil {
@@ -202,7 +245,10 @@ func (d *Tracker) Track() error {
 				d.podStatuses[podName] = podStatus
 			}
 			if d.lastObject != nil {
-				d.StatusReport <- NewDaemonSetStatus(d.lastObject.Status, d.podStatuses)
+				select {
+				case d.StatusReport <- d.newDaemonSetStatus():
+				case <-d.Context.Done():
+				}
 			}
 
 		case <-d.Context.Done():
@@ -249,11 +295,20 @@ func (d *Tracker) runDaemonSetInformer() {
 
 			switch e.Type {
 			case watch.Added:
-				d.resourceAdded <- object
+				select {
+				case d.resourceAdded <- object:
+				case <-d.Context.Done():
+				}
 			case watch.Modified:
-				d.resourceModified <- object
+				select {
+				case d.resourceModified <- object:
+				case <-d.Context.Done():
+				}
 			case watch.Deleted:
-				d.resourceDeleted <- object
+				select {
+				case d.resourceDeleted <- object:
+				case <-d.Context.Done():
+				}
 			case watch.Error:
 				err := fmt.Errorf("DaemonSet error: %v", e.Object)
 				//d.errors <- err
@@ -264,7 +319,10 @@ func (d *Tracker) runDaemonSetInformer() {
 		})
 
 		if err != nil {
-			d.errors <- err
+			select {
+			case d.errors <- err:
+			case <-d.Context.Done():
+			}
 		}
 
 		if debug.Debug() {
@@ -291,10 +349,11 @@ func (d *Tracker) runPodsInformer() {
 }
 
 func (d *Tracker) runPodTracker(podName string) error {
-	errorChan := make(chan error, 0)
-	doneChan := make(chan struct{}, 0)
+	errorChan := make(chan error, 1)
+	doneChan := make(chan struct{}, 1)
 
 	podTracker := pod.NewTracker(d.Context, podName, d.Namespace, d.Kube)
+	podTracker.FatalContainerReasons = d.FatalContainerReasons
 	if !d.LogsFromTime.IsZero() {
 		podTracker.LogsFromTime = d.LogsFromTime
 	}
@@ -329,7 +388,10 @@ func (d *Tracker) runPodTracker(podName string) error {
 					ReplicaSet: replicaset.ReplicaSet{},
 				}
 
-				d.PodLogChunk <- rsChunk
+				select {
+				case d.PodLogChunk <- rsChunk:
+				case <-d.Context.Done():
+				}
 			case containerError := <-podTracker.ContainerError:
 				podError := replicaset.ReplicaSetPodError{
 					PodError: pod.PodError{
@@ -339,20 +401,76 @@ func (d *Tracker) runPodTracker(podName string) error {
 					ReplicaSet: replicaset.ReplicaSet{},
 				}
 
-				d.PodError <- podError
+				select {
+				case d.PodError <- podError:
+				case <-d.Context.Done():
+				}
+			case termination := <-podTracker.ContainerTermination:
+				rsTermination := replicaset.ReplicaSetPodContainerTermination{
+					PodContainerTermination: pod.PodContainerTermination{
+						ContainerTermination: termination,
+						PodName:              podTracker.ResourceName,
+					},
+					ReplicaSet: replicaset.ReplicaSet{},
+				}
+
+				select {
+				case d.PodContainerTermination <- rsTermination:
+				case <-d.Context.Done():
+				}
+			case failure := <-podTracker.Unschedulable:
+				rsFailure := replicaset.ReplicaSetPodSchedulingFailure{
+					PodSchedulingFailure: pod.PodSchedulingFailure{
+						SchedulingFailure: failure,
+						PodName:           podTracker.ResourceName,
+					},
+					ReplicaSet: replicaset.ReplicaSet{},
+				}
+
+				select {
+				case d.PodUnschedulable <- rsFailure:
+				case <-d.Context.Done():
+				}
+			case restart := <-podTracker.ContainerRestart:
+				rsRestart := replicaset.ReplicaSetPodContainerRestart{
+					PodContainerRestart: pod.PodContainerRestart{
+						ContainerRestart: restart,
+						PodName:          podTracker.ResourceName,
+					},
+					ReplicaSet: replicaset.ReplicaSet{},
+				}
+
+				select {
+				case d.PodContainerRestart <- rsRestart:
+				case <-d.Context.Done():
+				}
 			case msg := <-podTracker.EventMsg:
-				d.EventMsg <- fmt.Sprintf("po/%s %s", podTracker.ResourceName, msg)
+				select {
+				case d.EventMsg <- fmt.Sprintf("po/%s %s", podTracker.ResourceName, msg):
+				case <-d.Context.Done():
+				}
 			case <-podTracker.Added:
 			case <-podTracker.Succeeded:
 			case <-podTracker.Failed:
 			case <-podTracker.Ready:
 			case podStatus := <-podTracker.StatusReport:
-				d.podStatusesReport <- map[string]pod.PodStatus{podTracker.ResourceName: podStatus}
+				select {
+				case d.podStatusesReport <- map[string]pod.PodStatus{podTracker.ResourceName: podStatus}:
+				case <-d.Context.Done():
+				}
 			case err := <-errorChan:
-				d.errors <- err
+				select {
+				case d.errors <- err:
+				case <-d.Context.Done():
+				}
 				return
 			case <-doneChan:
-				d.podDone <- podTracker.ResourceName
+				select {
+				case d.podDone <- podTracker.ResourceName:
+				case <-d.Context.Done():
+				}
+				return
+			case <-d.Context.Done():
 				return
 			}
 		}
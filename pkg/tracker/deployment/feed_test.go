@@ -0,0 +1,102 @@
+package deployment
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/flant/kubedog/pkg/tracker"
+	"github.com/flant/kubedog/pkg/tracker/replicaset"
+	"github.com/flant/kubedog/pkg/tracker/trackertest"
+)
+
+func TestFeedTrackStopsGoroutinesOnCancelWhileCallbackIsBlocked(t *testing.T) {
+	labels := map[string]string{"app": "myapp"}
+	replicas := int32(1)
+	isController := true
+
+	// Pending pod without running containers: the fake clientset cannot stream logs
+	kube := fake.NewSimpleClientset(
+		&extensions.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "mydeploy", Namespace: "myns", UID: "deploy-uid", Generation: 1},
+			Spec: extensions.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Image: "ubuntu"}}},
+				},
+			},
+		},
+		&extensions.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "mydeploy-5d8f",
+				Namespace:       "myns",
+				Labels:          labels,
+				OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "mydeploy", UID: "deploy-uid", Controller: &isController}},
+			},
+			Spec: extensions.ReplicaSetSpec{Replicas: &replicas, Selector: &metav1.LabelSelector{MatchLabels: labels}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "mydeploy-5d8f-abcde",
+				Namespace:       "myns",
+				Labels:          labels,
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "mydeploy-5d8f", Controller: &isController}},
+			},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Image: "ubuntu"}}},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "main",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}},
+				}},
+			},
+		},
+	)
+
+	goroutinesBefore := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	podAdded := make(chan struct{})
+	feed := NewFeed()
+	feed.OnAddedPod(func(replicaset.ReplicaSetPod) error {
+		close(podAdded)
+		// Deployment, replicaset and pod trackers goroutines keep sending while the callback is blocked
+		<-ctx.Done()
+		// Feed returns right away without reading channels of the trackers
+		return tracker.StopTrack
+	})
+
+	trackErr := make(chan error, 1)
+	go func() {
+		trackErr <- feed.Track("mydeploy", "myns", kube, tracker.Options{ParentContext: ctx})
+	}()
+
+	select {
+	case <-podAdded:
+	case <-time.After(10 * time.Second):
+		t.Fatal("deployment pod has not been added")
+	}
+
+	cancel()
+
+	select {
+	case err := <-trackErr:
+		if err != nil {
+			t.Fatalf("unexpected Track error: %s", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Track has not returned after the context cancel")
+	}
+
+	trackertest.WaitGoroutines(t, goroutinesBefore)
+}
@@ -33,6 +33,9 @@ type DeploymentStatus struct {
 	FailedReason string
 
 	ReadyStatus tracker.ReadyStatus
+
+	// Annotations are annotations of the tracked object
+	Annotations map[string]string
 }
 
 func NewDeploymentStatus(readyStatus tracker.ReadyStatus, isFailed bool, failedReason string, kubeSpec extensions.DeploymentSpec, kubeStatus extensions.DeploymentStatus, podsStatuses map[string]pod.PodStatus) DeploymentStatus {
@@ -50,9 +53,16 @@ func NewDeploymentStatus(readyStatus tracker.ReadyStatus, isFailed bool, failedR
 	return res
 }
 
+func (d *Tracker) newDeploymentStatus() DeploymentStatus {
+	status := NewDeploymentStatus(d.readyStatus, (d.State == "Failed"), d.failedReason, d.lastObject.Spec, d.lastObject.Status, d.podStatuses)
+	status.Annotations = d.lastObject.Annotations
+	return status
+}
+
 type Tracker struct {
 	tracker.Tracker
-	LogsFromTime time.Time
+	LogsFromTime          time.Time
+	FatalContainerReasons []string
 
 	CurrentReady bool
 
@@ -76,6 +86,10 @@ type Tracker struct {
 	PodError        chan replicaset.ReplicaSetPodError
 	StatusReport    chan DeploymentStatus
 
+	PodContainerTermination chan replicaset.ReplicaSetPodContainerTermination
+	PodUnschedulable        chan replicaset.ReplicaSetPodSchedulingFailure
+	PodContainerRestart     chan replicaset.ReplicaSetPodContainerRestart
+
 	resourceAdded         chan *extensions.Deployment
 	resourceModified      chan *extensions.Deployment
 	resourceDeleted       chan *extensions.Deployment
@@ -90,6 +104,10 @@ type Tracker struct {
 	replicaSetPodLogChunk chan *replicaset.ReplicaSetPodLogChunk
 	replicaSetPodError    chan replicaset.ReplicaSetPodError
 
+	replicaSetPodContainerTermination chan replicaset.ReplicaSetPodContainerTermination
+	replicaSetPodUnschedulable        chan replicaset.ReplicaSetPodSchedulingFailure
+	replicaSetPodContainerRestart     chan replicaset.ReplicaSetPodContainerRestart
+
 	TrackedPods []string
 }
 
@@ -106,7 +124,8 @@ func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Int
 			Context:          ctx,
 		},
 
-		LogsFromTime: opts.LogsFromTime,
+		LogsFromTime:          opts.LogsFromTime,
+		FatalContainerReasons: opts.FatalContainerReasons,
 
 		Added:           make(chan bool, 0),
 		Ready:           make(chan bool, 1),
@@ -119,6 +138,10 @@ func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Int
 		StatusReport:    make(chan DeploymentStatus, 100),
 		//PodReady:        make(chan bool, 1),
 
+		PodContainerTermination: make(chan replicaset.ReplicaSetPodContainerTermination, 0),
+		PodUnschedulable:        make(chan replicaset.ReplicaSetPodSchedulingFailure, 0),
+		PodContainerRestart:     make(chan replicaset.ReplicaSetPodContainerRestart, 0),
+
 		knownReplicaSets: make(map[string]*extensions.ReplicaSet),
 		podStatuses:      make(map[string]pod.PodStatus),
 		TrackedPods:      make([]string, 0),
@@ -137,6 +160,10 @@ func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Int
 		podStatusesReport:     make(chan map[string]pod.PodStatus),
 		replicaSetPodLogChunk: make(chan *replicaset.ReplicaSetPodLogChunk, 1000),
 		replicaSetPodError:    make(chan replicaset.ReplicaSetPodError, 1),
+
+		replicaSetPodContainerTermination: make(chan replicaset.ReplicaSetPodContainerTermination, 1),
+		replicaSetPodUnschedulable:        make(chan replicaset.ReplicaSetPodSchedulingFailure, 1),
+		replicaSetPodContainerRestart:     make(chan replicaset.ReplicaSetPodContainerRestart, 1),
 	}
 }
 
@@ -171,7 +198,10 @@ func (d *Tracker) Track() (err error) {
 			switch d.State {
 			case "":
 				d.State = "Started"
-				d.Added <- ready
+				select {
+				case d.Added <- ready:
+				case <-d.Context.Done():
+				}
 			}
 
 			d.runReplicaSetsInformer()
@@ -184,24 +214,39 @@ func (d *Tracker) Track() (err error) {
 				return err
 			}
 			if ready {
-				d.Ready <- true
+				select {
+				case d.Ready <- true:
+				case <-d.Context.Done():
+				}
 			}
 
 		case <-d.resourceDeleted:
 			d.lastObject = nil
-			d.StatusReport <- DeploymentStatus{}
+			select {
+			case d.StatusReport <- DeploymentStatus{}:
+			case <-d.Context.Done():
+			}
 
 			d.State = "Deleted"
-			d.Failed <- "resource deleted"
+			select {
+			case d.Failed <- "resource deleted":
+			case <-d.Context.Done():
+			}
 
 		case reason := <-d.resourceFailed:
 			d.State = "Failed"
 			d.failedReason = reason
 
 			if d.lastObject != nil {
-				d.StatusReport <- NewDeploymentStatus(d.readyStatus, (d.State == "Failed"), d.failedReason, d.lastObject.Spec, d.lastObject.Status, d.podStatuses)
+				select {
+				case d.StatusReport <- d.newDeploymentStatus():
+				case <-d.Context.Done():
+				}
+			}
+			select {
+			case d.Failed <- reason:
+			case <-d.Context.Done():
 			}
-			d.Failed <- reason
 
 		case rs := <-d.replicaSetAdded:
 			if debug.Debug() {
@@ -215,9 +260,12 @@ func (d *Tracker) Track() (err error) {
 				return err
 			}
 
-			d.AddedReplicaSet <- replicaset.ReplicaSet{
+			select {
+			case d.AddedReplicaSet <- replicaset.ReplicaSet{
 				Name:  rs.Name,
 				IsNew: rsNew,
+			}:
+			case <-d.Context.Done():
 			}
 
 		case rs := <-d.replicaSetModified:
@@ -249,7 +297,10 @@ func (d *Tracker) Track() (err error) {
 				},
 			}
 
-			d.AddedPod <- rsPod
+			select {
+			case d.AddedPod <- rsPod:
+			case <-d.Context.Done():
+			}
 
 			err = d.runPodTracker(pod.Name, rsName)
 			if err != nil {
@@ -270,7 +321,10 @@ func (d *Tracker) Track() (err error) {
 				d.podStatuses[podName] = podStatus
 			}
 			if d.lastObject != nil {
-				d.StatusReport <- NewDeploymentStatus(d.readyStatus, (d.State == "Failed"), d.failedReason, d.lastObject.Spec, d.lastObject.Status, d.podStatuses)
+				select {
+				case d.StatusReport <- d.newDeploymentStatus():
+				case <-d.Context.Done():
+				}
 			}
 
 		case rsChunk := <-d.replicaSetPodLogChunk:
@@ -279,7 +333,10 @@ func (d *Tracker) Track() (err error) {
 				return err
 			}
 			rsChunk.ReplicaSet.IsNew = rsNew
-			d.PodLogChunk <- rsChunk
+			select {
+			case d.PodLogChunk <- rsChunk:
+			case <-d.Context.Done():
+			}
 
 		case rsPodError := <-d.replicaSetPodError:
 			rsNew, err := utils.IsReplicaSetNew(d.lastObject, d.knownReplicaSets, rsPodError.ReplicaSet.Name)
@@ -287,7 +344,43 @@ func (d *Tracker) Track() (err error) {
 				return err
 			}
 			rsPodError.ReplicaSet.IsNew = rsNew
-			d.PodError <- rsPodError
+			select {
+			case d.PodError <- rsPodError:
+			case <-d.Context.Done():
+			}
+
+		case rsTermination := <-d.replicaSetPodContainerTermination:
+			rsNew, err := utils.IsReplicaSetNew(d.lastObject, d.knownReplicaSets, rsTermination.ReplicaSet.Name)
+			if err != nil {
+				return err
+			}
+			rsTermination.ReplicaSet.IsNew = rsNew
+			select {
+			case d.PodContainerTermination <- rsTermination:
+			case <-d.Context.Done():
+			}
+
+		case rsFailure := <-d.replicaSetPodUnschedulable:
+			rsNew, err := utils.IsReplicaSetNew(d.lastObject, d.knownReplicaSets, rsFailure.ReplicaSet.Name)
+			if err != nil {
+				return err
+			}
+			rsFailure.ReplicaSet.IsNew = rsNew
+			select {
+			case d.PodUnschedulable <- rsFailure:
+			case <-d.Context.Done():
+			}
+
+		case rsRestart := <-d.replicaSetPodContainerRestart:
+			rsNew, err := utils.IsReplicaSetNew(d.lastObject, d.knownReplicaSets, rsRestart.ReplicaSet.Name)
+			if err != nil {
+				return err
+			}
+			rsRestart.ReplicaSet.IsNew = rsNew
+			select {
+			case d.PodContainerRestart <- rsRestart:
+			case <-d.Context.Done():
+			}
 
 		case <-d.Context.Done():
 			return tracker.ErrTrackInterrupted
@@ -335,11 +428,20 @@ func (d *Tracker) runDeploymentInformer() {
 
 			switch e.Type {
 			case watch.Added:
-				d.resourceAdded <- object
+				select {
+				case d.resourceAdded <- object:
+				case <-d.Context.Done():
+				}
 			case watch.Modified:
-				d.resourceModified <- object
+				select {
+				case d.resourceModified <- object:
+				case <-d.Context.Done():
+				}
 			case watch.Deleted:
-				d.resourceDeleted <- object
+				select {
+				case d.resourceDeleted <- object:
+				case <-d.Context.Done():
+				}
 			case watch.Error:
 				err := fmt.Errorf("deployment error: %v", e.Object)
 				//d.errors <- err
@@ -350,7 +452,10 @@ func (d *Tracker) runDeploymentInformer() {
 		})
 
 		if err != nil {
-			d.errors <- err
+			select {
+			case d.errors <- err:
+			case <-d.Context.Done():
+			}
 		}
 
 		if debug.Debug() {
@@ -392,10 +497,11 @@ func (d *Tracker) runPodsInformer() {
 }
 
 func (d *Tracker) runPodTracker(podName, rsName string) error {
-	errorChan := make(chan error, 0)
-	doneChan := make(chan struct{}, 0)
+	errorChan := make(chan error, 1)
+	doneChan := make(chan struct{}, 1)
 
 	podTracker := pod.NewTracker(d.Context, podName, d.Namespace, d.Kube)
+	podTracker.FatalContainerReasons = d.FatalContainerReasons
 	if !d.LogsFromTime.IsZero() {
 		podTracker.LogsFromTime = d.LogsFromTime
 	}
@@ -431,7 +537,10 @@ func (d *Tracker) runPodTracker(podName, rsName string) error {
 						Name: rsName,
 					},
 				}
-				d.replicaSetPodLogChunk <- rsChunk
+				select {
+				case d.replicaSetPodLogChunk <- rsChunk:
+				case <-d.Context.Done():
+				}
 
 			case containerError := <-podTracker.ContainerError:
 				podError := replicaset.ReplicaSetPodError{
@@ -443,21 +552,83 @@ func (d *Tracker) runPodTracker(podName, rsName string) error {
 						Name: rsName,
 					},
 				}
-				d.replicaSetPodError <- podError
+				select {
+				case d.replicaSetPodError <- podError:
+				case <-d.Context.Done():
+				}
+
+			case termination := <-podTracker.ContainerTermination:
+				rsTermination := replicaset.ReplicaSetPodContainerTermination{
+					PodContainerTermination: pod.PodContainerTermination{
+						ContainerTermination: termination,
+						PodName:              podTracker.ResourceName,
+					},
+					ReplicaSet: replicaset.ReplicaSet{
+						Name: rsName,
+					},
+				}
+				select {
+				case d.replicaSetPodContainerTermination <- rsTermination:
+				case <-d.Context.Done():
+				}
+
+			case failure := <-podTracker.Unschedulable:
+				rsFailure := replicaset.ReplicaSetPodSchedulingFailure{
+					PodSchedulingFailure: pod.PodSchedulingFailure{
+						SchedulingFailure: failure,
+						PodName:           podTracker.ResourceName,
+					},
+					ReplicaSet: replicaset.ReplicaSet{
+						Name: rsName,
+					},
+				}
+				select {
+				case d.replicaSetPodUnschedulable <- rsFailure:
+				case <-d.Context.Done():
+				}
+
+			case restart := <-podTracker.ContainerRestart:
+				rsRestart := replicaset.ReplicaSetPodContainerRestart{
+					PodContainerRestart: pod.PodContainerRestart{
+						ContainerRestart: restart,
+						PodName:          podTracker.ResourceName,
+					},
+					ReplicaSet: replicaset.ReplicaSet{
+						Name: rsName,
+					},
+				}
+				select {
+				case d.replicaSetPodContainerRestart <- rsRestart:
+				case <-d.Context.Done():
+				}
 
 			case msg := <-podTracker.EventMsg:
-				d.EventMsg <- fmt.Sprintf("po/%s %s", podTracker.ResourceName, msg)
+				select {
+				case d.EventMsg <- fmt.Sprintf("po/%s %s", podTracker.ResourceName, msg):
+				case <-d.Context.Done():
+				}
 			case <-podTracker.Added:
 			case <-podTracker.Succeeded:
 			case <-podTracker.Failed:
 			case <-podTracker.Ready:
 			case podStatus := <-podTracker.StatusReport:
-				d.podStatusesReport <- map[string]pod.PodStatus{podTracker.ResourceName: podStatus}
+				select {
+				case d.podStatusesReport <- map[string]pod.PodStatus{podTracker.ResourceName: podStatus}:
+				case <-d.Context.Done():
+				}
 			case err := <-errorChan:
-				d.errors <- err
+				select {
+				case d.errors <- err:
+				case <-d.Context.Done():
+				}
 				return
 			case <-doneChan:
-				d.podDone <- podTracker.ResourceName
+				select {
+				case d.podDone <- podTracker.ResourceName:
+				case <-d.Context.Done():
+				}
+				return
+			case <-d.Context.Done():
 				return
 			}
 		}
@@ -495,7 +666,10 @@ func (d *Tracker) handleDeploymentState(object *extensions.Deployment) (ready bo
 	d.CurrentReady = d.readyStatus.IsReady
 	d.lastObject = object
 
-	d.StatusReport <- NewDeploymentStatus(d.readyStatus, (d.State == "Failed"), d.failedReason, d.lastObject.Spec, d.lastObject.Status, d.podStatuses)
+	select {
+	case d.StatusReport <- d.newDeploymentStatus():
+	case <-d.Context.Done():
+	}
 
 	if prevReady == false && d.CurrentReady == true {
 		d.FinalDeploymentStatus = newStatus
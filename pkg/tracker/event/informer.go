@@ -24,6 +24,12 @@ type EventInformer struct {
 	Messages chan string
 	Failures chan string
 	Errors   chan error
+	// Objects receives every new event of the resource when set
+	Objects chan *corev1.Event
+	// InitialEvents are events of the resource existed before Run, they are not sent to channels
+	InitialEvents []corev1.Event
+
+	handledReasons map[string]bool
 
 	initialEventUids map[types.UID]bool
 }
@@ -54,6 +60,17 @@ func (e *EventInformer) WithChannels(msgCh chan string, failCh chan string, erro
 	return e
 }
 
+// WithObjects sets the channel for every new event of the resource.
+// Events with handledReasons are handled by the receiver of the channel and are not sent to Failures channel.
+func (e *EventInformer) WithObjects(objects chan *corev1.Event, handledReasons ...string) *EventInformer {
+	e.Objects = objects
+	e.handledReasons = make(map[string]bool)
+	for _, reason := range handledReasons {
+		e.handledReasons[reason] = true
+	}
+	return e
+}
+
 // runEventsInformer watch for StatefulSet events
 func (e *EventInformer) Run() {
 	e.handleInitialEvents()
@@ -117,7 +134,10 @@ func (e *EventInformer) Run() {
 		})
 
 		if err != nil {
-			e.Errors <- err
+			select {
+			case e.Errors <- err:
+			case <-e.Context.Done():
+			}
 		}
 
 		if debug.Debug() {
@@ -142,6 +162,7 @@ func (e *EventInformer) handleInitialEvents() {
 	for _, ev := range evList.Items {
 		e.initialEventUids[ev.UID] = true
 	}
+	e.InitialEvents = evList.Items
 }
 
 // handleEvent sends a message to Messages channel for all events and a message to Failures channel for Failed events
@@ -162,12 +183,25 @@ func (e *EventInformer) handleEvent(event *corev1.Event) {
 		fmt.Printf("  %s got normal event: %s %s\n", e.FullResourceName, event.Reason, event.Message)
 	}
 
-	e.Messages <- fmt.Sprintf("%s: %s", reason, event.Message)
+	select {
+	case e.Messages <- fmt.Sprintf("%s: %s", reason, event.Message):
+	case <-e.Context.Done():
+	}
 
-	if strings.Contains(reason, "Failed") {
+	if e.Objects != nil {
+		select {
+		case e.Objects <- event:
+		case <-e.Context.Done():
+		}
+	}
+
+	if strings.Contains(reason, "Failed") && !e.handledReasons[reason] {
 		if debug.Debug() {
 			fmt.Printf("got FAILED EVENT!!! %s %s\n", event.Reason, event.Message)
 		}
-		e.Failures <- fmt.Sprintf("%s: %s", reason, event.Message)
+		select {
+		case e.Failures <- fmt.Sprintf("%s: %s", reason, event.Message):
+		case <-e.Context.Done():
+		}
 	}
 }
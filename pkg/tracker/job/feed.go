@@ -21,6 +21,12 @@ type Feed interface {
 	OnAddedPod(func(podName string) error)
 	OnPodLogChunk(func(*pod.PodLogChunk) error)
 	OnPodError(func(pod.PodError) error)
+	// OnPodContainerTermination is called once for every crashed container instance with its termination details and last log lines
+	OnPodContainerTermination(func(pod.PodContainerTermination) error)
+	// OnPodUnschedulable is called when the pod cannot be scheduled and when the scheduling failure reasons change
+	OnPodUnschedulable(func(pod.PodSchedulingFailure) error)
+	// OnPodContainerRestart is called when the container restart count grows since the start of tracking
+	OnPodContainerRestart(func(pod.PodContainerRestart) error)
 	OnStatusReport(func(JobStatus) error)
 
 	GetStatus() JobStatus
@@ -41,6 +47,10 @@ type feed struct {
 	OnPodErrorFunc     func(pod.PodError) error
 	OnStatusReportFunc func(JobStatus) error
 
+	OnPodContainerTerminationFunc func(pod.PodContainerTermination) error
+	OnPodUnschedulableFunc        func(pod.PodSchedulingFailure) error
+	OnPodContainerRestartFunc     func(pod.PodContainerRestart) error
+
 	statusMux sync.Mutex
 	status    JobStatus
 }
@@ -66,13 +76,22 @@ func (f *feed) OnPodLogChunk(function func(*pod.PodLogChunk) error) {
 func (f *feed) OnPodError(function func(pod.PodError) error) {
 	f.OnPodErrorFunc = function
 }
+func (f *feed) OnPodContainerTermination(function func(pod.PodContainerTermination) error) {
+	f.OnPodContainerTerminationFunc = function
+}
+func (f *feed) OnPodUnschedulable(function func(pod.PodSchedulingFailure) error) {
+	f.OnPodUnschedulableFunc = function
+}
+func (f *feed) OnPodContainerRestart(function func(pod.PodContainerRestart) error) {
+	f.OnPodContainerRestartFunc = function
+}
 func (f *feed) OnStatusReport(function func(JobStatus) error) {
 	f.OnStatusReportFunc = function
 }
 
 func (f *feed) Track(name, namespace string, kube kubernetes.Interface, opts tracker.Options) error {
-	errorChan := make(chan error, 0)
-	doneChan := make(chan struct{}, 0)
+	errorChan := make(chan error, 1)
+	doneChan := make(chan struct{}, 1)
 
 	parentContext := opts.ParentContext
 	if parentContext == nil {
@@ -82,6 +101,7 @@ func (f *feed) Track(name, namespace string, kube kubernetes.Interface, opts tra
 	defer cancel()
 
 	job := NewTracker(ctx, name, namespace, kube)
+	job.FatalContainerReasons = opts.FatalContainerReasons
 
 	go func() {
 		err := job.Track()
@@ -202,6 +222,51 @@ func (f *feed) Track(name, namespace string, kube kubernetes.Interface, opts tra
 				}
 			}
 
+		case termination := <-job.PodContainerTermination:
+			if debug.Debug() {
+				fmt.Printf("    job/%s po/%s container/%s terminated: %s\n", job.ResourceName, termination.PodName, termination.ContainerName, termination.Summary())
+			}
+
+			if f.OnPodContainerTerminationFunc != nil {
+				err := f.OnPodContainerTerminationFunc(termination)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case failure := <-job.PodUnschedulable:
+			if debug.Debug() {
+				fmt.Printf("    job/%s po/%s unschedulable: %s\n", job.ResourceName, failure.PodName, failure.Message)
+			}
+
+			if f.OnPodUnschedulableFunc != nil {
+				err := f.OnPodUnschedulableFunc(failure)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case restart := <-job.PodContainerRestart:
+			if debug.Debug() {
+				fmt.Printf("    job/%s po/%s container/%s restarted: %s\n", job.ResourceName, restart.PodName, restart.ContainerName, restart.Summary())
+			}
+
+			if f.OnPodContainerRestartFunc != nil {
+				err := f.OnPodContainerRestartFunc(restart)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
 		case status := <-job.StatusReport:
 			f.setStatus(status)
 
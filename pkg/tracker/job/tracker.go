@@ -24,6 +24,9 @@ import (
 type JobStatus struct {
 	batchv1.JobStatus
 	Pods map[string]pod.PodStatus
+
+	// Annotations are annotations of the tracked object
+	Annotations map[string]string
 }
 
 func NewJobStatus(kubeStatus batchv1.JobStatus, podsStatuses map[string]pod.PodStatus) JobStatus {
@@ -37,6 +40,12 @@ func NewJobStatus(kubeStatus batchv1.JobStatus, podsStatuses map[string]pod.PodS
 	return res
 }
 
+func (job *Tracker) newJobStatus() JobStatus {
+	status := NewJobStatus(job.lastObject.Status, job.podStatuses)
+	status.Annotations = job.lastObject.Annotations
+	return status
+}
+
 type Tracker struct {
 	tracker.Tracker
 
@@ -49,10 +58,16 @@ type Tracker struct {
 	PodError     chan pod.PodError
 	StatusReport chan JobStatus
 
+	PodContainerTermination chan pod.PodContainerTermination
+	PodUnschedulable        chan pod.PodSchedulingFailure
+	PodContainerRestart     chan pod.PodContainerRestart
+
 	State          tracker.TrackerState
 	TrackedPods    []string
 	FinalJobStatus batchv1.JobStatus
 
+	FatalContainerReasons []string
+
 	lastObject  *batchv1.Job
 	podStatuses map[string]pod.PodStatus
 
@@ -84,6 +99,10 @@ func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Int
 		PodError:     make(chan pod.PodError, 0),
 		StatusReport: make(chan JobStatus, 100),
 
+		PodContainerTermination: make(chan pod.PodContainerTermination, 0),
+		PodUnschedulable:        make(chan pod.PodSchedulingFailure, 0),
+		PodContainerRestart:     make(chan pod.PodContainerRestart, 0),
+
 		podStatuses: make(map[string]pod.PodStatus),
 
 		State:       tracker.Initial,
@@ -111,14 +130,20 @@ func (job *Tracker) Track() error {
 		select {
 		case object := <-job.objectAdded:
 			job.lastObject = object
-			job.StatusReport <- NewJobStatus(job.lastObject.Status, job.podStatuses)
+			select {
+			case job.StatusReport <- job.newJobStatus():
+			case <-job.Context.Done():
+			}
 
 			job.runEventsInformer()
 
 			switch job.State {
 			case tracker.Initial:
 				job.State = tracker.ResourceAdded
-				job.Added <- struct{}{}
+				select {
+				case job.Added <- struct{}{}:
+				case <-job.Context.Done():
+				}
 
 				err = job.runPodsTrackers(object)
 				if err != nil {
@@ -136,7 +161,10 @@ func (job *Tracker) Track() error {
 
 		case object := <-job.objectModified:
 			job.lastObject = object
-			job.StatusReport <- NewJobStatus(job.lastObject.Status, job.podStatuses)
+			select {
+			case job.StatusReport <- job.newJobStatus():
+			case <-job.Context.Done():
+			}
 
 			done, err := job.handleJobState(object)
 			if err != nil {
@@ -148,10 +176,16 @@ func (job *Tracker) Track() error {
 
 		case reason := <-job.objectFailed:
 			job.lastObject = nil
-			job.StatusReport <- JobStatus{}
+			select {
+			case job.StatusReport <- JobStatus{}:
+			case <-job.Context.Done():
+			}
 
 			job.State = "Failed"
-			job.Failed <- reason
+			select {
+			case job.Failed <- reason:
+			case <-job.Context.Done():
+			}
 
 		case <-job.objectDeleted:
 			if debug.Debug() {
@@ -181,7 +215,10 @@ func (job *Tracker) Track() error {
 				job.podStatuses[podName] = podStatus
 			}
 			if job.lastObject != nil {
-				job.StatusReport <- NewJobStatus(job.lastObject.Status, job.podStatuses)
+				select {
+				case job.StatusReport <- job.newJobStatus():
+				case <-job.Context.Done():
+				}
 			}
 
 		case <-job.Context.Done():
@@ -224,18 +261,30 @@ func (job *Tracker) runInformer() error {
 			}
 
 			if e.Type == watch.Added {
-				job.objectAdded <- object
+				select {
+				case job.objectAdded <- object:
+				case <-job.Context.Done():
+				}
 			} else if e.Type == watch.Modified {
-				job.objectModified <- object
+				select {
+				case job.objectModified <- object:
+				case <-job.Context.Done():
+				}
 			} else if e.Type == watch.Deleted {
-				job.objectDeleted <- object
+				select {
+				case job.objectDeleted <- object:
+				case <-job.Context.Done():
+				}
 			}
 
 			return false, nil
 		})
 
 		if err != nil {
-			job.errors <- err
+			select {
+			case job.errors <- err:
+			case <-job.Context.Done():
+			}
 		}
 
 		if debug.Debug() {
@@ -260,11 +309,17 @@ func (job *Tracker) handleJobState(object *batchv1.Job) (done bool, err error) {
 			if c.Type == batchv1.JobComplete && c.Status == corev1.ConditionTrue {
 				job.State = tracker.ResourceSucceeded
 				job.FinalJobStatus = object.Status
-				job.Succeeded <- struct{}{}
+				select {
+				case job.Succeeded <- struct{}{}:
+				case <-job.Context.Done():
+				}
 				done = true
 			} else if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
 				job.State = tracker.ResourceFailed
-				job.Failed <- c.Reason
+				select {
+				case job.Failed <- c.Reason:
+				case <-job.Context.Done():
+				}
 				done = true
 			}
 		}
@@ -332,7 +387,10 @@ func (job *Tracker) runPodsTrackers(object *batchv1.Job) error {
 		})
 
 		if err != nil {
-			job.errors <- err
+			select {
+			case job.errors <- err:
+			case <-job.Context.Done():
+			}
 		}
 
 		if debug.Debug() {
@@ -344,13 +402,17 @@ func (job *Tracker) runPodsTrackers(object *batchv1.Job) error {
 }
 
 func (job *Tracker) runPodTracker(podName string) error {
-	errorChan := make(chan error, 0)
-	doneChan := make(chan struct{}, 0)
+	errorChan := make(chan error, 1)
+	doneChan := make(chan struct{}, 1)
 
 	podTracker := pod.NewTracker(job.Context, podName, job.Namespace, job.Kube)
+	podTracker.FatalContainerReasons = job.FatalContainerReasons
 	job.TrackedPods = append(job.TrackedPods, podName)
 
-	job.AddedPod <- podTracker.ResourceName
+	select {
+	case job.AddedPod <- podTracker.ResourceName:
+	case <-job.Context.Done():
+	}
 
 	go func() {
 		if debug.Debug() {
@@ -374,23 +436,61 @@ func (job *Tracker) runPodTracker(podName string) error {
 			select {
 			case chunk := <-podTracker.ContainerLogChunk:
 				podChunk := &pod.PodLogChunk{ContainerLogChunk: chunk, PodName: podTracker.ResourceName}
-				job.PodLogChunk <- podChunk
+				select {
+				case job.PodLogChunk <- podChunk:
+				case <-job.Context.Done():
+				}
 			case containerError := <-podTracker.ContainerError:
 				podError := pod.PodError{ContainerError: containerError, PodName: podTracker.ResourceName}
-				job.PodError <- podError
+				select {
+				case job.PodError <- podError:
+				case <-job.Context.Done():
+				}
+			case termination := <-podTracker.ContainerTermination:
+				podTermination := pod.PodContainerTermination{ContainerTermination: termination, PodName: podTracker.ResourceName}
+				select {
+				case job.PodContainerTermination <- podTermination:
+				case <-job.Context.Done():
+				}
+			case failure := <-podTracker.Unschedulable:
+				podFailure := pod.PodSchedulingFailure{SchedulingFailure: failure, PodName: podTracker.ResourceName}
+				select {
+				case job.PodUnschedulable <- podFailure:
+				case <-job.Context.Done():
+				}
+			case restart := <-podTracker.ContainerRestart:
+				podRestart := pod.PodContainerRestart{ContainerRestart: restart, PodName: podTracker.ResourceName}
+				select {
+				case job.PodContainerRestart <- podRestart:
+				case <-job.Context.Done():
+				}
 			case msg := <-podTracker.EventMsg:
-				job.EventMsg <- fmt.Sprintf("po/%s %s", podTracker.ResourceName, msg)
+				select {
+				case job.EventMsg <- fmt.Sprintf("po/%s %s", podTracker.ResourceName, msg):
+				case <-job.Context.Done():
+				}
 			case <-podTracker.Added:
 			case <-podTracker.Succeeded:
 			case <-podTracker.Failed:
 			case <-podTracker.Ready:
 			case podStatus := <-podTracker.StatusReport:
-				job.podStatusesReport <- map[string]pod.PodStatus{podTracker.ResourceName: podStatus}
+				select {
+				case job.podStatusesReport <- map[string]pod.PodStatus{podTracker.ResourceName: podStatus}:
+				case <-job.Context.Done():
+				}
 			case err := <-errorChan:
-				job.errors <- err
+				select {
+				case job.errors <- err:
+				case <-job.Context.Done():
+				}
 				return
 			case <-doneChan:
-				job.podDone <- podTracker.ResourceName
+				select {
+				case job.podDone <- podTracker.ResourceName:
+				case <-job.Context.Done():
+				}
+				return
+			case <-job.Context.Done():
 				return
 			}
 		}
@@ -0,0 +1,100 @@
+package pod
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ContainerErrorType classifies container errors so callers can tell misconfiguration apart from image problems and crashes
+type ContainerErrorType string
+
+const (
+	// ConfigContainerError is caused by invalid pod configuration, e.g. missing ConfigMap or Secret key
+	ConfigContainerError ContainerErrorType = "config"
+	// ImageContainerError is caused by the container image which cannot be pulled or is invalid
+	ImageContainerError ContainerErrorType = "image"
+	// RuntimeContainerError is caused by the container which cannot be started, crashes or is killed
+	RuntimeContainerError ContainerErrorType = "runtime"
+)
+
+// DefaultFatalContainerReasons are container waiting and termination reasons and pod status reasons
+// reported as container errors when tracker.Options.FatalContainerReasons is not set
+var DefaultFatalContainerReasons = []string{
+	"ImagePullBackOff",
+	"ErrImagePull",
+	"InvalidImageName",
+	"CrashLoopBackOff",
+	"CreateContainerConfigError",
+	"CreateContainerError",
+	"RunContainerError",
+	"OOMKilled",
+	"Evicted",
+}
+
+var containerErrorTypes = map[string]ContainerErrorType{
+	"ImagePullBackOff":           ImageContainerError,
+	"ErrImagePull":               ImageContainerError,
+	"ErrImageNeverPull":          ImageContainerError,
+	"InvalidImageName":           ImageContainerError,
+	"ImageInspectError":          ImageContainerError,
+	"RegistryUnavailable":        ImageContainerError,
+	"CreateContainerConfigError": ConfigContainerError,
+	"CrashLoopBackOff":           RuntimeContainerError,
+	"CreateContainerError":       RuntimeContainerError,
+	"RunContainerError":          RuntimeContainerError,
+	"OOMKilled":                  RuntimeContainerError,
+	"Evicted":                    RuntimeContainerError,
+}
+
+// ContainerErrorTypeByReason returns the type of the container error by its reason,
+// unknown reasons are considered RuntimeContainerError
+func ContainerErrorTypeByReason(reason string) ContainerErrorType {
+	if errorType, hasKey := containerErrorTypes[reason]; hasKey {
+		return errorType
+	}
+	return RuntimeContainerError
+}
+
+func newContainerError(containerName, reason, message string) ContainerError {
+	return ContainerError{
+		ContainerName: containerName,
+		Reason:        reason,
+		Type:          ContainerErrorTypeByReason(reason),
+		Message:       message,
+	}
+}
+
+func (pod *Tracker) isFatalContainerReason(reason string) bool {
+	fatalReasons := pod.FatalContainerReasons
+	if fatalReasons == nil {
+		fatalReasons = DefaultFatalContainerReasons
+	}
+
+	for _, fatalReason := range fatalReasons {
+		if fatalReason == reason {
+			return true
+		}
+	}
+	return false
+}
+
+func (pod *Tracker) sendContainerError(containerError ContainerError) {
+	select {
+	case pod.ContainerError <- containerError:
+	case <-pod.Context.Done():
+	}
+}
+
+// handlePodStatusReason reports the fatal pod status reason like Evicted once per pod
+// as an error of the first pod container
+func (pod *Tracker) handlePodStatusReason(object *corev1.Pod) {
+	reason := object.Status.Reason
+	if reason == "" || pod.reportedPodStatusReason == reason || !pod.isFatalContainerReason(reason) || len(object.Spec.Containers) == 0 {
+		return
+	}
+	pod.reportedPodStatusReason = reason
+
+	message := fmt.Sprintf("%s: %s", reason, object.Status.Message)
+	pod.sendContainerError(newContainerError(object.Spec.Containers[0].Name, reason, message))
+}
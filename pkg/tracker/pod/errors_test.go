@@ -0,0 +1,114 @@
+package pod
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestContainerErrorTypeByReason(t *testing.T) {
+	tests := []struct {
+		reason   string
+		expected ContainerErrorType
+	}{
+		{reason: "CreateContainerConfigError", expected: ConfigContainerError},
+		{reason: "ImagePullBackOff", expected: ImageContainerError},
+		{reason: "ErrImagePull", expected: ImageContainerError},
+		{reason: "ErrImageNeverPull", expected: ImageContainerError},
+		{reason: "InvalidImageName", expected: ImageContainerError},
+		{reason: "CrashLoopBackOff", expected: RuntimeContainerError},
+		{reason: "RunContainerError", expected: RuntimeContainerError},
+		{reason: "OOMKilled", expected: RuntimeContainerError},
+		{reason: "Evicted", expected: RuntimeContainerError},
+		{reason: "SomethingUnknown", expected: RuntimeContainerError},
+		{reason: "", expected: RuntimeContainerError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.reason, func(t *testing.T) {
+			if errorType := ContainerErrorTypeByReason(test.reason); errorType != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, errorType)
+			}
+		})
+	}
+}
+
+func TestIsFatalContainerReason(t *testing.T) {
+	tests := []struct {
+		name         string
+		fatalReasons []string
+		reason       string
+		expected     bool
+	}{
+		{name: "default image reason", reason: "ImagePullBackOff", expected: true},
+		{name: "default config reason", reason: "CreateContainerConfigError", expected: true},
+		{name: "default pod status reason", reason: "Evicted", expected: true},
+		{name: "not default reason", reason: "ContainerCannotRun"},
+		{name: "overridden reason", fatalReasons: []string{"ContainerCannotRun"}, reason: "ContainerCannotRun", expected: true},
+		{name: "default reason not in override", fatalReasons: []string{"ContainerCannotRun"}, reason: "OOMKilled"},
+		{name: "empty override", fatalReasons: []string{}, reason: "CrashLoopBackOff"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := NewTracker(context.Background(), "mypod", "myns", fake.NewSimpleClientset())
+			pod.FatalContainerReasons = test.fatalReasons
+
+			if fatal := pod.isFatalContainerReason(test.reason); fatal != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, fatal)
+			}
+		})
+	}
+}
+
+func TestHandlePodStateEvicted(t *testing.T) {
+	evicted := &corev1.Pod{
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "main"}, {Name: "sidecar"}}},
+		Status: corev1.PodStatus{
+			Phase:   corev1.PodFailed,
+			Reason:  "Evicted",
+			Message: "The node was low on resource: memory.",
+		},
+	}
+
+	pod := NewTracker(context.Background(), "mypod", "myns", fake.NewSimpleClientset())
+
+	errors := []ContainerError{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 2; i++ {
+			if finished, err := pod.handlePodState(evicted); err != nil || !finished {
+				t.Errorf("expected pod to be done without error, got done %v, error %v", finished, err)
+			}
+		}
+	}()
+
+	for finished := false; !finished; {
+		select {
+		case containerError := <-pod.ContainerError:
+			errors = append(errors, containerError)
+		case <-done:
+			finished = true
+		}
+	}
+
+	select {
+	case reason := <-pod.Failed:
+		t.Errorf("unexpected failed reason %q", reason)
+	default:
+	}
+
+	expected := []ContainerError{{
+		ContainerName: "main",
+		Reason:        "Evicted",
+		Type:          RuntimeContainerError,
+		Message:       "Evicted: The node was low on resource: memory.",
+	}}
+	if !reflect.DeepEqual(errors, expected) {
+		t.Errorf("expected %#v, got %#v", expected, errors)
+	}
+}
@@ -19,6 +19,12 @@ type Feed interface {
 	OnReady(func() error)
 	OnContainerLogChunk(func(*ContainerLogChunk) error)
 	OnContainerError(func(ContainerError) error)
+	// OnContainerTermination is called once for every crashed container instance with its termination details and last log lines
+	OnContainerTermination(func(ContainerTermination) error)
+	// OnUnschedulable is called when the pod cannot be scheduled and when the scheduling failure reasons change
+	OnUnschedulable(func(SchedulingFailure) error)
+	// OnContainerRestart is called when the container restart count grows since the start of tracking
+	OnContainerRestart(func(ContainerRestart) error)
 	OnStatusReport(func(PodStatus) error)
 
 	GetStatus() PodStatus
@@ -30,14 +36,17 @@ func NewFeed() Feed {
 }
 
 type feed struct {
-	OnAddedFunc             func() error
-	OnSucceededFunc         func() error
-	OnFailedFunc            func(string) error
-	OnEventMsgFunc          func(string) error
-	OnReadyFunc             func() error
-	OnContainerLogChunkFunc func(*ContainerLogChunk) error
-	OnContainerErrorFunc    func(ContainerError) error
-	OnStatusReportFunc      func(PodStatus) error
+	OnAddedFunc                func() error
+	OnSucceededFunc            func() error
+	OnFailedFunc               func(string) error
+	OnEventMsgFunc             func(string) error
+	OnReadyFunc                func() error
+	OnContainerLogChunkFunc    func(*ContainerLogChunk) error
+	OnContainerErrorFunc       func(ContainerError) error
+	OnContainerTerminationFunc func(ContainerTermination) error
+	OnUnschedulableFunc        func(SchedulingFailure) error
+	OnContainerRestartFunc     func(ContainerRestart) error
+	OnStatusReportFunc         func(PodStatus) error
 
 	statusMux sync.Mutex
 	status    PodStatus
@@ -64,13 +73,22 @@ func (f *feed) OnContainerLogChunk(function func(*ContainerLogChunk) error) {
 func (f *feed) OnContainerError(function func(ContainerError) error) {
 	f.OnContainerErrorFunc = function
 }
+func (f *feed) OnContainerTermination(function func(ContainerTermination) error) {
+	f.OnContainerTerminationFunc = function
+}
+func (f *feed) OnUnschedulable(function func(SchedulingFailure) error) {
+	f.OnUnschedulableFunc = function
+}
+func (f *feed) OnContainerRestart(function func(ContainerRestart) error) {
+	f.OnContainerRestartFunc = function
+}
 func (f *feed) OnStatusReport(function func(PodStatus) error) {
 	f.OnStatusReportFunc = function
 }
 
 func (f *feed) Track(name, namespace string, kube kubernetes.Interface, opts tracker.Options) error {
-	errorChan := make(chan error, 0)
-	doneChan := make(chan struct{}, 0)
+	errorChan := make(chan error, 1)
+	doneChan := make(chan struct{}, 1)
 
 	parentContext := opts.ParentContext
 	if parentContext == nil {
@@ -80,6 +98,7 @@ func (f *feed) Track(name, namespace string, kube kubernetes.Interface, opts tra
 	defer cancel()
 
 	pod := NewTracker(ctx, name, namespace, kube)
+	pod.FatalContainerReasons = opts.FatalContainerReasons
 
 	go func() {
 		err := pod.Start()
@@ -125,6 +144,51 @@ func (f *feed) Track(name, namespace string, kube kubernetes.Interface, opts tra
 				}
 			}
 
+		case termination := <-pod.ContainerTermination:
+			if debug.Debug() {
+				fmt.Printf("Pod `%s` container `%s` terminated: %s\n", pod.ResourceName, termination.ContainerName, termination.Summary())
+			}
+
+			if f.OnContainerTerminationFunc != nil {
+				err := f.OnContainerTerminationFunc(termination)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case failure := <-pod.Unschedulable:
+			if debug.Debug() {
+				fmt.Printf("Pod `%s` unschedulable: %s\n", pod.ResourceName, failure.Message)
+			}
+
+			if f.OnUnschedulableFunc != nil {
+				err := f.OnUnschedulableFunc(failure)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case restart := <-pod.ContainerRestart:
+			if debug.Debug() {
+				fmt.Printf("Pod `%s` container `%s` restarted: %s\n", pod.ResourceName, restart.ContainerName, restart.Summary())
+			}
+
+			if f.OnContainerRestartFunc != nil {
+				err := f.OnContainerRestartFunc(restart)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
 		case <-pod.Added:
 			if debug.Debug() {
 				fmt.Printf("Pod `%s` added\n", pod.ResourceName)
@@ -0,0 +1,71 @@
+package pod
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/flant/kubedog/pkg/tracker"
+	"github.com/flant/kubedog/pkg/tracker/trackertest"
+)
+
+func TestFeedTrackStopsGoroutinesOnCancelWhileCallbackIsBlocked(t *testing.T) {
+	// Pending pod without running containers: the fake clientset cannot stream logs
+	kube := fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "mypod", Namespace: "myns"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "main", Image: "ubuntu"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "main",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}},
+			}},
+		},
+	})
+
+	goroutinesBefore := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	added := make(chan struct{})
+	feed := NewFeed()
+	feed.OnAdded(func() error {
+		close(added)
+		// Tracker goroutines keep sending statuses and events while the callback is blocked
+		<-ctx.Done()
+		// Feed returns right away without reading channels of the trackers
+		return tracker.StopTrack
+	})
+
+	trackErr := make(chan error, 1)
+	go func() {
+		trackErr <- feed.Track("mypod", "myns", kube, tracker.Options{ParentContext: ctx})
+	}()
+
+	select {
+	case <-added:
+	case <-time.After(10 * time.Second):
+		t.Fatal("pod has not been added")
+	}
+
+	cancel()
+
+	select {
+	case err := <-trackErr:
+		if err != nil {
+			t.Fatalf("unexpected Track error: %s", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Track has not returned after the context cancel")
+	}
+
+	trackertest.WaitGoroutines(t, goroutinesBefore)
+}
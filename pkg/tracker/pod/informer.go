@@ -91,7 +91,10 @@ func (p *PodsInformer) Run() {
 
 			switch e.Type {
 			case watch.Added:
-				p.PodAdded <- object
+				select {
+				case p.PodAdded <- object:
+				case <-p.Context.Done():
+				}
 				// case watch.Modified:
 				// 	d.resourceModified <- object
 				// case watch.Deleted:
@@ -102,7 +105,10 @@ func (p *PodsInformer) Run() {
 		})
 
 		if err != nil {
-			p.Errors <- err
+			select {
+			case p.Errors <- err:
+			case <-p.Context.Done():
+			}
 		}
 
 		if debug.Debug() {
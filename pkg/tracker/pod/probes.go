@@ -0,0 +1,174 @@
+package pod
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+type ProbeType string
+
+const (
+	ReadinessProbe ProbeType = "readiness"
+	LivenessProbe  ProbeType = "liveness"
+	StartupProbe   ProbeType = "startup"
+)
+
+// ProbeFailure aggregates Unhealthy events of the container probe
+type ProbeFailure struct {
+	ContainerName string
+	ProbeType     ProbeType
+	// Count is the number of the probe failures since the first of them
+	Count int
+	// LastMessage is the short message of the last probe failure, e.g. HTTP 503 on /healthz
+	LastMessage string
+	FirstSeen   time.Time
+	LastSeen    time.Time
+}
+
+// Summary returns the probe failure: readiness probe failing 14x: HTTP 503 on /healthz
+func (failure ProbeFailure) Summary() string {
+	summary := fmt.Sprintf("%s probe failing %dx", failure.ProbeType, failure.Count)
+	if failure.LastMessage != "" {
+		summary += fmt.Sprintf(": %s", failure.LastMessage)
+	}
+	return summary
+}
+
+var (
+	probeFailureMessageRegexp = regexp.MustCompile(`(?s)^(Readiness|Liveness|Startup) probe (?:failed|errored):\s*(.*)$`)
+	httpProbeStatusCodeRegexp = regexp.MustCompile(`^HTTP probe failed with statuscode: (\d+)`)
+	containerFieldPathRegexp  = regexp.MustCompile(`^spec\.(?:initContainers|containers)\{(.+)\}$`)
+)
+
+// handleInitialProbeEvents saves counts of Unhealthy events existed before the start of tracking:
+// these failures are not reported, only failures counted by later updates of the events are
+func (pod *Tracker) handleInitialProbeEvents(events []corev1.Event) {
+	for i := range events {
+		if events[i].Reason == "Unhealthy" {
+			pod.probeEventCounts[events[i].UID] = probeEventCount(&events[i])
+		}
+	}
+}
+
+func probeEventCount(event *corev1.Event) int32 {
+	if event.Count == 0 {
+		return 1
+	}
+	return event.Count
+}
+
+// handleProbeEvent aggregates Unhealthy event of the probe by container and probe type and sends the updated status
+func (pod *Tracker) handleProbeEvent(event *corev1.Event) {
+	fieldPathMatch := containerFieldPathRegexp.FindStringSubmatch(event.InvolvedObject.FieldPath)
+	messageMatch := probeFailureMessageRegexp.FindStringSubmatch(event.Message)
+	if fieldPathMatch == nil || messageMatch == nil {
+		return
+	}
+
+	containerName := fieldPathMatch[1]
+	probeType := ProbeType(strings.ToLower(messageMatch[1]))
+
+	// The same event is updated by kubelet with the growing count on every probe failure
+	count := probeEventCount(event)
+	newFailures := int(count - pod.probeEventCounts[event.UID])
+	if newFailures <= 0 {
+		return
+	}
+	pod.probeEventCounts[event.UID] = count
+
+	lastSeen := event.LastTimestamp.Time
+	if lastSeen.IsZero() {
+		lastSeen = time.Now()
+	}
+	firstSeen := event.FirstTimestamp.Time
+	if firstSeen.IsZero() {
+		firstSeen = lastSeen
+	}
+
+	key := fmt.Sprintf("%s/%s", containerName, probeType)
+	failure, hasKey := pod.probeFailures[key]
+	if !hasKey {
+		failure = ProbeFailure{ContainerName: containerName, ProbeType: probeType, FirstSeen: firstSeen}
+	}
+	if firstSeen.Before(failure.FirstSeen) {
+		failure.FirstSeen = firstSeen
+	}
+	if lastSeen.After(failure.LastSeen) {
+		failure.LastSeen = lastSeen
+	}
+	failure.Count += newFailures
+	failure.LastMessage = pod.probeFailureShortMessage(containerName, probeType, messageMatch[2])
+	pod.probeFailures[key] = failure
+
+	if pod.lastObject != nil {
+		select {
+		case pod.StatusReport <- pod.newPodStatus():
+		case <-pod.Context.Done():
+		}
+	}
+}
+
+// probeFailureShortMessage returns the first line of the probe output,
+// HTTP status code is shown with the probe path: HTTP 503 on /healthz
+func (pod *Tracker) probeFailureShortMessage(containerName string, probeType ProbeType, message string) string {
+	message = strings.TrimSpace(message)
+	if i := strings.Index(message, "\n"); i >= 0 {
+		message = strings.TrimSpace(message[:i])
+	}
+
+	match := httpProbeStatusCodeRegexp.FindStringSubmatch(message)
+	if match == nil {
+		return message
+	}
+
+	if probe := pod.containerProbe(containerName, probeType); probe != nil && probe.HTTPGet != nil {
+		return fmt.Sprintf("HTTP %s on %s", match[1], probe.HTTPGet.Path)
+	}
+	return fmt.Sprintf("HTTP %s", match[1])
+}
+
+// containerProbe returns the readiness or liveness probe of the container from the pod spec
+func (pod *Tracker) containerProbe(containerName string, probeType ProbeType) *corev1.Probe {
+	if pod.lastObject == nil {
+		return nil
+	}
+
+	containers := []corev1.Container{}
+	containers = append(containers, pod.lastObject.Spec.InitContainers...)
+	containers = append(containers, pod.lastObject.Spec.Containers...)
+
+	for _, container := range containers {
+		if container.Name != containerName {
+			continue
+		}
+
+		switch probeType {
+		case ReadinessProbe:
+			return container.ReadinessProbe
+		case LivenessProbe:
+			return container.LivenessProbe
+		}
+	}
+
+	return nil
+}
+
+// probeFailuresList returns aggregated probe failures ordered by container name and probe type
+func (pod *Tracker) probeFailuresList() []ProbeFailure {
+	failures := []ProbeFailure{}
+	for _, failure := range pod.probeFailures {
+		failures = append(failures, failure)
+	}
+	sort.Slice(failures, func(i, j int) bool {
+		if failures[i].ContainerName != failures[j].ContainerName {
+			return failures[i].ContainerName < failures[j].ContainerName
+		}
+		return failures[i].ProbeType < failures[j].ProbeType
+	})
+	return failures
+}
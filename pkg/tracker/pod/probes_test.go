@@ -0,0 +1,99 @@
+package pod
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestHandleProbeEvents(t *testing.T) {
+	newEvent := func(uid types.UID, container, message string, count int32) corev1.Event {
+		return corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{UID: uid},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "mypod", FieldPath: "spec.containers{" + container + "}"},
+			Reason:         "Unhealthy",
+			Message:        message,
+			Count:          count,
+		}
+	}
+	readinessFailed := "Readiness probe failed: HTTP probe failed with statuscode: 503"
+	livenessFailed := "Liveness probe failed: dial tcp 10.0.0.1:8080: connect: connection refused\nmore output"
+
+	tests := []struct {
+		name          string
+		initialEvents []corev1.Event
+		events        []corev1.Event
+		expected      []string
+	}{
+		{
+			name: "new events",
+			events: []corev1.Event{
+				newEvent("1", "main", readinessFailed, 1),
+				newEvent("1", "main", readinessFailed, 3),
+				newEvent("2", "sidecar", livenessFailed, 0),
+			},
+			expected: []string{
+				"main: readiness probe failing 3x: HTTP 503 on /healthz",
+				"sidecar: liveness probe failing 1x: dial tcp 10.0.0.1:8080: connect: connection refused",
+			},
+		},
+		{
+			name:          "events before tracking",
+			initialEvents: []corev1.Event{newEvent("1", "main", readinessFailed, 40), newEvent("2", "sidecar", livenessFailed, 7)},
+			events: []corev1.Event{
+				newEvent("1", "main", readinessFailed, 40),
+				newEvent("1", "main", readinessFailed, 42),
+				newEvent("2", "sidecar", livenessFailed, 7),
+			},
+			expected: []string{"main: readiness probe failing 2x: HTTP 503 on /healthz"},
+		},
+		{
+			name: "repeated and outdated events",
+			events: []corev1.Event{
+				newEvent("1", "main", readinessFailed, 2),
+				newEvent("1", "main", readinessFailed, 2),
+				newEvent("1", "main", readinessFailed, 1),
+				newEvent("2", "main", readinessFailed, 1),
+			},
+			expected: []string{"main: readiness probe failing 3x: HTTP 503 on /healthz"},
+		},
+		{
+			name: "not probe events",
+			initialEvents: []corev1.Event{
+				{ObjectMeta: metav1.ObjectMeta{UID: "3"}, Reason: "Pulled", Count: 5},
+			},
+			events: []corev1.Event{
+				newEvent("1", "main", "Container is unhealthy", 1),
+				{ObjectMeta: metav1.ObjectMeta{UID: "2"}, Reason: "Unhealthy", Message: readinessFailed, Count: 1},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := NewTracker(context.Background(), "mypod", "myns", fake.NewSimpleClientset())
+			pod.lastObject = &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "main", ReadinessProbe: &corev1.Probe{Handler: corev1.Handler{HTTPGet: &corev1.HTTPGetAction{Path: "/healthz"}}}},
+				{Name: "sidecar"},
+			}}}
+
+			pod.handleInitialProbeEvents(test.initialEvents)
+			for i := range test.events {
+				pod.handleProbeEvent(&test.events[i])
+			}
+
+			failures := []string{}
+			for _, failure := range pod.probeFailuresList() {
+				failures = append(failures, failure.ContainerName+": "+failure.Summary())
+			}
+			if len(failures)+len(test.expected) > 0 && !reflect.DeepEqual(failures, test.expected) {
+				t.Errorf("expected %q, got %q", test.expected, failures)
+			}
+		})
+	}
+}
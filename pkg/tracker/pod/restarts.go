@@ -0,0 +1,63 @@
+package pod
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ContainerRestart describes the container restart noticed by the tracker
+type ContainerRestart struct {
+	ContainerName string
+	// RestartCount is the total number of the container restarts from the pod status
+	RestartCount int32
+	// Restarts is the number of the container restarts since the start of tracking
+	Restarts int32
+	// LastTermination is the termination of the previous container instance, nil when unknown
+	LastTermination *ContainerTermination
+}
+
+// Summary returns human readable restart details: 3 restarts since tracking started, last termination: exit code 1, reason Error
+func (restart ContainerRestart) Summary() string {
+	summary := fmt.Sprintf("%d restarts since tracking started", restart.Restarts)
+	if restart.LastTermination != nil {
+		summary += fmt.Sprintf(", last termination: %s", restart.LastTermination.Summary())
+	}
+	return summary
+}
+
+type PodContainerRestart struct {
+	ContainerRestart
+	PodName string
+}
+
+// handleContainerRestart sends ContainerRestart when RestartCount of the container has grown,
+// RestartCount first seen by the tracker is the base for Restarts
+func (pod *Tracker) handleContainerRestart(cs corev1.ContainerStatus) {
+	lastRestartCount, hasKey := pod.containerRestartCounts[cs.Name]
+	if !hasKey {
+		pod.initialContainerRestartCounts[cs.Name] = cs.RestartCount
+		pod.containerRestartCounts[cs.Name] = cs.RestartCount
+		return
+	}
+
+	if cs.RestartCount <= lastRestartCount {
+		return
+	}
+	pod.containerRestartCounts[cs.Name] = cs.RestartCount
+
+	restart := ContainerRestart{
+		ContainerName: cs.Name,
+		RestartCount:  cs.RestartCount,
+		Restarts:      cs.RestartCount - pod.initialContainerRestartCounts[cs.Name],
+	}
+	if terminated := cs.LastTerminationState.Terminated; terminated != nil {
+		termination := newContainerTermination(cs, terminated)
+		restart.LastTermination = &termination
+	}
+
+	select {
+	case pod.ContainerRestart <- restart:
+	case <-pod.Context.Done():
+	}
+}
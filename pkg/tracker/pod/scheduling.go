@@ -0,0 +1,148 @@
+package pod
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SchedulingFailure describes why the pod cannot be scheduled, it is parsed from the scheduler message:
+// 0/12 nodes are available: 3 Insufficient cpu, 9 node(s) had taints that the pod didn't tolerate.
+type SchedulingFailure struct {
+	// Message is the scheduler message as is
+	Message        string
+	AvailableNodes int
+	TotalNodes     int
+	// Reasons are the reasons why nodes do not fit the pod with the number of such nodes,
+	// empty when the message has unknown format
+	Reasons []SchedulingFailureReason
+	// Since is the time when the pod has become unschedulable
+	Since time.Time
+}
+
+type SchedulingFailureReason struct {
+	Reason string
+	Nodes  int
+}
+
+var (
+	schedulingFailureMessageRegexp = regexp.MustCompile(`^(\d+)/(\d+) nodes are available:\s*(.*)$`)
+	schedulingFailureReasonRegexp  = regexp.MustCompile(`^(\d+) (.+)$`)
+)
+
+// ParseSchedulingFailureMessage parses the scheduler message of the PodScheduled condition or FailedScheduling event
+func ParseSchedulingFailureMessage(message string) SchedulingFailure {
+	failure := SchedulingFailure{Message: message}
+
+	match := schedulingFailureMessageRegexp.FindStringSubmatch(strings.TrimSpace(message))
+	if match == nil {
+		return failure
+	}
+
+	failure.AvailableNodes, _ = strconv.Atoi(match[1])
+	failure.TotalNodes, _ = strconv.Atoi(match[2])
+
+	// newer schedulers append the result of preemption: "... . preemption: 0/12 nodes are available: ..."
+	reasons := match[3]
+	if i := strings.Index(reasons, " preemption:"); i >= 0 {
+		reasons = reasons[:i]
+	}
+	reasons = strings.TrimSuffix(strings.TrimSpace(reasons), ".")
+
+	for _, part := range strings.Split(reasons, ", ") {
+		reasonMatch := schedulingFailureReasonRegexp.FindStringSubmatch(strings.TrimSpace(part))
+		if reasonMatch == nil {
+			continue
+		}
+
+		nodes, _ := strconv.Atoi(reasonMatch[1])
+		failure.Reasons = append(failure.Reasons, SchedulingFailureReason{
+			Reason: strings.TrimSuffix(reasonMatch[2], "."),
+			Nodes:  nodes,
+		})
+	}
+
+	return failure
+}
+
+// Summary returns the scheduling failure with reasons of the most nodes first:
+// 0/12 nodes available: 9 node(s) had taints that the pod didn't tolerate, 3 Insufficient cpu
+func (failure SchedulingFailure) Summary() string {
+	if len(failure.Reasons) == 0 {
+		return failure.Message
+	}
+
+	reasons := make([]SchedulingFailureReason, len(failure.Reasons))
+	copy(reasons, failure.Reasons)
+	sort.SliceStable(reasons, func(i, j int) bool {
+		return reasons[i].Nodes > reasons[j].Nodes
+	})
+
+	parts := []string{}
+	for _, reason := range reasons {
+		parts = append(parts, fmt.Sprintf("%d %s", reason.Nodes, reason.Reason))
+	}
+
+	return fmt.Sprintf("%d/%d nodes available: %s", failure.AvailableNodes, failure.TotalNodes, strings.Join(parts, ", "))
+}
+
+// GetSchedulingFailure returns the scheduling failure from the PodScheduled=False condition with Unschedulable reason
+func GetSchedulingFailure(status corev1.PodStatus) (SchedulingFailure, bool) {
+	for _, cond := range status.Conditions {
+		if cond.Type != corev1.PodScheduled || cond.Status != corev1.ConditionFalse || cond.Reason != corev1.PodReasonUnschedulable {
+			continue
+		}
+
+		failure := ParseSchedulingFailureMessage(cond.Message)
+		failure.Since = cond.LastTransitionTime.Time
+		return failure, true
+	}
+
+	return SchedulingFailure{}, false
+}
+
+type PodSchedulingFailure struct {
+	SchedulingFailure
+	PodName string
+}
+
+// handleSchedulingFailure sends SchedulingFailure when the scheduler message of the unschedulable pod has changed
+func (pod *Tracker) handleSchedulingFailure(failure SchedulingFailure) {
+	if failure.Message == pod.lastSchedulingFailureMessage {
+		return
+	}
+	pod.lastSchedulingFailureMessage = failure.Message
+
+	if failure.Since.IsZero() {
+		failure.Since = time.Now()
+		if pod.lastObject != nil {
+			if conditionFailure, ok := GetSchedulingFailure(pod.lastObject.Status); ok {
+				failure.Since = conditionFailure.Since
+			}
+		}
+	}
+
+	select {
+	case pod.Unschedulable <- failure:
+	case <-pod.Context.Done():
+	}
+}
+
+// handlePodScheduling handles the PodScheduled condition of the pod
+func (pod *Tracker) handlePodScheduling(object *corev1.Pod) {
+	if failure, ok := GetSchedulingFailure(object.Status); ok {
+		pod.handleSchedulingFailure(failure)
+		return
+	}
+
+	for _, cond := range object.Status.Conditions {
+		if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionTrue {
+			pod.lastSchedulingFailureMessage = ""
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package pod
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseSchedulingFailureMessage(t *testing.T) {
+	tests := []struct {
+		name           string
+		message        string
+		availableNodes int
+		totalNodes     int
+		reasons        []SchedulingFailureReason
+		summary        string
+	}{
+		{
+			name:           "several reasons",
+			message:        "0/12 nodes are available: 3 Insufficient cpu, 9 node(s) had taints that the pod didn't tolerate.",
+			availableNodes: 0,
+			totalNodes:     12,
+			reasons: []SchedulingFailureReason{
+				{Reason: "Insufficient cpu", Nodes: 3},
+				{Reason: "node(s) had taints that the pod didn't tolerate", Nodes: 9},
+			},
+			summary: "0/12 nodes available: 9 node(s) had taints that the pod didn't tolerate, 3 Insufficient cpu",
+		},
+		{
+			name:           "equal number of nodes keeps the order",
+			message:        "1/5 nodes are available: 2 Insufficient memory, 2 Insufficient cpu.",
+			availableNodes: 1,
+			totalNodes:     5,
+			reasons: []SchedulingFailureReason{
+				{Reason: "Insufficient memory", Nodes: 2},
+				{Reason: "Insufficient cpu", Nodes: 2},
+			},
+			summary: "1/5 nodes available: 2 Insufficient memory, 2 Insufficient cpu",
+		},
+		{
+			name:           "preemption result",
+			message:        "0/3 nodes are available: 3 Insufficient cpu. preemption: 0/3 nodes are available: 3 No preemption victims found for incoming pod.",
+			availableNodes: 0,
+			totalNodes:     3,
+			reasons:        []SchedulingFailureReason{{Reason: "Insufficient cpu", Nodes: 3}},
+			summary:        "0/3 nodes available: 3 Insufficient cpu",
+		},
+		{
+			name:           "unknown reasons are skipped",
+			message:        "0/2 nodes are available: 1 node(s) didn't match node selector, node(s) were unschedulable.",
+			availableNodes: 0,
+			totalNodes:     2,
+			reasons:        []SchedulingFailureReason{{Reason: "node(s) didn't match node selector", Nodes: 1}},
+			summary:        "0/2 nodes available: 1 node(s) didn't match node selector",
+		},
+		{
+			name:           "no reasons",
+			message:        "0/0 nodes are available: ",
+			availableNodes: 0,
+			totalNodes:     0,
+			summary:        "0/0 nodes are available: ",
+		},
+		{
+			name:    "unknown format",
+			message: "no nodes available to schedule pods",
+			summary: "no nodes available to schedule pods",
+		},
+		{
+			name:    "empty",
+			message: "",
+			summary: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			failure := ParseSchedulingFailureMessage(test.message)
+
+			if failure.Message != test.message {
+				t.Errorf("expected message %q, got %q", test.message, failure.Message)
+			}
+			if failure.AvailableNodes != test.availableNodes || failure.TotalNodes != test.totalNodes {
+				t.Errorf("expected %d/%d nodes, got %d/%d", test.availableNodes, test.totalNodes, failure.AvailableNodes, failure.TotalNodes)
+			}
+			if !reflect.DeepEqual(failure.Reasons, test.reasons) {
+				t.Errorf("expected reasons %v, got %v", test.reasons, failure.Reasons)
+			}
+			if summary := failure.Summary(); summary != test.summary {
+				t.Errorf("expected summary %q, got %q", test.summary, summary)
+			}
+		})
+	}
+}
+
+func TestGetSchedulingFailure(t *testing.T) {
+	since := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	message := "0/3 nodes are available: 3 Insufficient cpu."
+
+	tests := []struct {
+		name       string
+		conditions []corev1.PodCondition
+		ok         bool
+	}{
+		{
+			name: "unschedulable",
+			conditions: []corev1.PodCondition{
+				{Type: corev1.PodInitialized, Status: corev1.ConditionTrue},
+				{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable, Message: message, LastTransitionTime: metav1.NewTime(since)},
+			},
+			ok: true,
+		},
+		{
+			name:       "scheduled",
+			conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionTrue}},
+		},
+		{
+			name:       "not scheduled for other reason",
+			conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: "SchedulerError", Message: message}},
+		},
+		{
+			name: "no conditions",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			failure, ok := GetSchedulingFailure(corev1.PodStatus{Conditions: test.conditions})
+			if ok != test.ok {
+				t.Fatalf("expected ok %v, got %v", test.ok, ok)
+			}
+			if !ok {
+				return
+			}
+
+			if failure.Message != message || failure.TotalNodes != 3 || len(failure.Reasons) != 1 {
+				t.Errorf("expected parsed scheduler message %q, got %#v", message, failure)
+			}
+			if !failure.Since.Equal(since) {
+				t.Errorf("expected since %s, got %s", since, failure.Since)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package pod
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/flant/kubedog/pkg/display"
+	"github.com/flant/kubedog/pkg/tracker/debug"
+)
+
+// terminatedContainerLogsTailLines is the number of the last log lines of the terminated container instance
+// included in ContainerTermination
+const terminatedContainerLogsTailLines int64 = 20
+
+// ContainerTermination describes the crashed container instance: the terminated container
+// or the previous instance of the restarted container
+type ContainerTermination struct {
+	ContainerName string
+	RestartCount  int32
+
+	ExitCode int32
+	Signal   int32
+	// Reason is a brief reason of the termination, e.g. OOMKilled or Error
+	Reason string
+	// Message is the content of the container terminationMessagePath file
+	Message    string
+	StartedAt  time.Time
+	FinishedAt time.Time
+
+	// LogLines are the last log lines of the terminated container instance
+	LogLines []display.LogLine
+}
+
+// Summary returns human readable termination details: exit code 137, signal 9, reason OOMKilled: message
+func (termination ContainerTermination) Summary() string {
+	parts := []string{fmt.Sprintf("exit code %d", termination.ExitCode)}
+	if termination.Signal != 0 {
+		parts = append(parts, fmt.Sprintf("signal %d", termination.Signal))
+	}
+	if termination.Reason != "" {
+		parts = append(parts, fmt.Sprintf("reason %s", termination.Reason))
+	}
+
+	summary := strings.Join(parts, ", ")
+	if message := strings.TrimSpace(termination.Message); message != "" {
+		summary += fmt.Sprintf(": %s", message)
+	}
+	return summary
+}
+
+type PodContainerTermination struct {
+	ContainerTermination
+	PodName string
+}
+
+func newContainerTermination(cs corev1.ContainerStatus, terminated *corev1.ContainerStateTerminated) ContainerTermination {
+	return ContainerTermination{
+		ContainerName: cs.Name,
+		RestartCount:  cs.RestartCount,
+		ExitCode:      terminated.ExitCode,
+		Signal:        terminated.Signal,
+		Reason:        terminated.Reason,
+		Message:       terminated.Message,
+		StartedAt:     terminated.StartedAt.Time,
+		FinishedAt:    terminated.FinishedAt.Time,
+	}
+}
+
+// isContainerCrashed returns true when the container instance has not completed successfully
+func isContainerCrashed(terminated *corev1.ContainerStateTerminated) bool {
+	return terminated.ExitCode != 0 || terminated.Signal != 0 || terminated.Reason == "OOMKilled"
+}
+
+// containerTerminationKey returns the id of the terminated container instance
+func containerTerminationKey(cs corev1.ContainerStatus, terminated *corev1.ContainerStateTerminated) string {
+	if terminated.ContainerID != "" {
+		return terminated.ContainerID
+	}
+	return fmt.Sprintf("%s/%s", cs.Name, terminated.FinishedAt.String())
+}
+
+// handleContainerTermination sends ContainerTermination once for every crashed container instance:
+// the terminated container or the previous instance of the restarted container.
+// Termination seen when the container is seen first is the base like RestartCount for restarts: it has happened
+// before the start of tracking and is not reported.
+func (pod *Tracker) handleContainerTermination(cs corev1.ContainerStatus) {
+	terminated := cs.State.Terminated
+	previous := false
+	if terminated == nil {
+		terminated = cs.LastTerminationState.Terminated
+		previous = true
+	}
+
+	if !pod.seenContainerTerminations[cs.Name] {
+		pod.seenContainerTerminations[cs.Name] = true
+		if terminated != nil {
+			pod.reportedContainerTerminations[containerTerminationKey(cs, terminated)] = true
+		}
+		return
+	}
+
+	if terminated == nil || !isContainerCrashed(terminated) {
+		return
+	}
+
+	key := containerTerminationKey(cs, terminated)
+	if pod.reportedContainerTerminations[key] {
+		return
+	}
+	pod.reportedContainerTerminations[key] = true
+
+	termination := newContainerTermination(cs, terminated)
+
+	logLines, err := pod.getTerminatedContainerLogs(cs.Name, previous)
+	if err != nil {
+		if debug.Debug() {
+			fmt.Fprintf(os.Stderr, "Pod `%s` Container `%s` terminated container logs error: %s\n", pod.ResourceName, cs.Name, err)
+		}
+	}
+	termination.LogLines = logLines
+
+	select {
+	case pod.ContainerTermination <- termination:
+	case <-pod.Context.Done():
+	}
+
+	// Termination reasons like OOMKilled are reported once for every container instance
+	if pod.isFatalContainerReason(terminated.Reason) {
+		pod.erroredContainerTerminations[key] = true
+		pod.sendContainerError(newContainerError(cs.Name, terminated.Reason, fmt.Sprintf("%s: %s", terminated.Reason, termination.Summary())))
+	}
+}
+
+// getTerminatedContainerLogs gets the last log lines of the terminated container instance,
+// previous is set when the container has already been restarted
+func (pod *Tracker) getTerminatedContainerLogs(containerName string, previous bool) ([]display.LogLine, error) {
+	tailLines := terminatedContainerLogsTailLines
+	logOpts := &corev1.PodLogOptions{
+		Container:  containerName,
+		Timestamps: true,
+		Previous:   previous,
+		TailLines:  &tailLines,
+	}
+
+	data, err := pod.Kube.CoreV1().
+		Pods(pod.Namespace).
+		GetLogs(pod.ResourceName, logOpts).
+		Context(pod.Context).
+		DoRaw()
+	if err != nil {
+		return nil, err
+	}
+
+	logLines := []display.LogLine{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if logLine, ok := parseLogLine(line); ok {
+			logLines = append(logLines, logLine)
+		}
+	}
+
+	return logLines, nil
+}
+
+// parseLogLine parses log line with timestamp returned by kubernetes: 2019-05-20T12:00:01.123456789Z message
+func parseLogLine(line string) (display.LogLine, bool) {
+	lineParts := strings.SplitN(line, " ", 2)
+	if len(lineParts) != 2 {
+		return display.LogLine{}, false
+	}
+	return display.LogLine{Timestamp: lineParts[0], Message: lineParts[1]}, true
+}
@@ -0,0 +1,222 @@
+package pod
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	restfake "k8s.io/client-go/rest/fake"
+)
+
+func TestHandleContainersStateTerminations(t *testing.T) {
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	crashLoopBackOff := corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 10s"}}
+	crashed := func(id, reason string, exitCode int32) *corev1.ContainerStateTerminated {
+		return &corev1.ContainerStateTerminated{ContainerID: id, Reason: reason, ExitCode: exitCode}
+	}
+
+	tests := []struct {
+		name     string
+		statuses []corev1.ContainerStatus
+		expected []string
+	}{
+		{
+			name: "crash before tracking",
+			statuses: []corev1.ContainerStatus{
+				{Name: "main", State: running, RestartCount: 3, LastTerminationState: corev1.ContainerState{Terminated: crashed("docker://1", "OOMKilled", 137)}},
+				{Name: "main", State: running, RestartCount: 3, LastTerminationState: corev1.ContainerState{Terminated: crashed("docker://1", "OOMKilled", 137)}},
+			},
+		},
+		{
+			name: "terminated before tracking and restarted",
+			statuses: []corev1.ContainerStatus{
+				{Name: "main", State: corev1.ContainerState{Terminated: crashed("docker://1", "Error", 1)}},
+				{Name: "main", State: running, RestartCount: 1, LastTerminationState: corev1.ContainerState{Terminated: crashed("docker://1", "Error", 1)}},
+			},
+			expected: []string{"restart main: 1 restarts since tracking started, last termination: exit code 1, reason Error"},
+		},
+		{
+			name: "crash during tracking",
+			statuses: []corev1.ContainerStatus{
+				{Name: "main", State: running},
+				{Name: "main", State: corev1.ContainerState{Terminated: crashed("docker://1", "Error", 1)}},
+				{Name: "main", State: running, RestartCount: 1, LastTerminationState: corev1.ContainerState{Terminated: crashed("docker://1", "Error", 1)}},
+			},
+			expected: []string{
+				"termination main: exit code 1, reason Error, logs: [panic: boom]",
+				"restart main: 1 restarts since tracking started, last termination: exit code 1, reason Error",
+			},
+		},
+		{
+			name: "new crash after crash before tracking",
+			statuses: []corev1.ContainerStatus{
+				{Name: "main", State: running, RestartCount: 3, LastTerminationState: corev1.ContainerState{Terminated: crashed("docker://1", "Error", 1)}},
+				{Name: "main", State: running, RestartCount: 4, LastTerminationState: corev1.ContainerState{Terminated: crashed("docker://2", "Error", 2)}},
+			},
+			expected: []string{
+				"termination main: exit code 2, reason Error, logs: [panic: boom]",
+				"restart main: 1 restarts since tracking started, last termination: exit code 2, reason Error",
+			},
+		},
+		{
+			name: "fatal reason during tracking",
+			statuses: []corev1.ContainerStatus{
+				{Name: "main", State: running},
+				{Name: "main", State: running, RestartCount: 1, LastTerminationState: corev1.ContainerState{Terminated: crashed("docker://1", "OOMKilled", 137)}},
+			},
+			expected: []string{
+				"termination main: exit code 137, reason OOMKilled, logs: [panic: boom]",
+				"error main: OOMKilled: exit code 137, reason OOMKilled",
+				"restart main: 1 restarts since tracking started, last termination: exit code 137, reason OOMKilled",
+			},
+		},
+		{
+			name: "crash loop back off after fatal termination",
+			statuses: []corev1.ContainerStatus{
+				{Name: "main", State: running},
+				{Name: "main", State: running, RestartCount: 1, LastTerminationState: corev1.ContainerState{Terminated: crashed("docker://1", "OOMKilled", 137)}},
+				{Name: "main", State: crashLoopBackOff, RestartCount: 1, LastTerminationState: corev1.ContainerState{Terminated: crashed("docker://1", "OOMKilled", 137)}},
+			},
+			expected: []string{
+				"termination main: exit code 137, reason OOMKilled, logs: [panic: boom]",
+				"error main: OOMKilled: exit code 137, reason OOMKilled",
+				"restart main: 1 restarts since tracking started, last termination: exit code 137, reason OOMKilled",
+			},
+		},
+		{
+			name: "crash loop back off",
+			statuses: []corev1.ContainerStatus{
+				{Name: "main", State: running},
+				{Name: "main", State: crashLoopBackOff, RestartCount: 1, LastTerminationState: corev1.ContainerState{Terminated: crashed("docker://1", "Error", 1)}},
+			},
+			expected: []string{
+				"termination main: exit code 1, reason Error, logs: [panic: boom]",
+				"restart main: 1 restarts since tracking started, last termination: exit code 1, reason Error",
+				"error main: CrashLoopBackOff: back-off 10s (last termination: exit code 1, reason Error)",
+			},
+		},
+		{
+			name: "crash loop back off before tracking",
+			statuses: []corev1.ContainerStatus{
+				{Name: "main", State: crashLoopBackOff, RestartCount: 5, LastTerminationState: corev1.ContainerState{Terminated: crashed("docker://1", "OOMKilled", 137)}},
+			},
+			expected: []string{
+				"error main: CrashLoopBackOff: back-off 10s (last termination: exit code 137, reason OOMKilled)",
+			},
+		},
+		{
+			name: "completed container",
+			statuses: []corev1.ContainerStatus{
+				{Name: "main", State: running},
+				{Name: "main", State: corev1.ContainerState{Terminated: crashed("docker://1", "Completed", 0)}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := NewTracker(context.Background(), "mypod", "myns", newLogsClientset("2019-01-01T00:00:00Z panic: boom\n"))
+
+			objects := []*corev1.Pod{}
+			for _, cs := range test.statuses {
+				objects = append(objects, &corev1.Pod{
+					Spec:   corev1.PodSpec{RestartPolicy: corev1.RestartPolicyAlways},
+					Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{cs}},
+				})
+			}
+
+			if events := handleContainersStates(t, pod, objects); len(events)+len(test.expected) > 0 && !reflect.DeepEqual(events, test.expected) {
+				t.Errorf("expected events:\n%s\ngot:\n%s", strings.Join(test.expected, "\n"), strings.Join(events, "\n"))
+			}
+		})
+	}
+}
+
+// handleContainersStates handles pod objects one by one and returns sent terminations, restarts and errors
+func handleContainersStates(t *testing.T, pod *Tracker, objects []*corev1.Pod) []string {
+	done := make(chan error)
+	go func() {
+		for _, object := range objects {
+			if err := pod.handleContainersState(object); err != nil {
+				done <- err
+				return
+			}
+		}
+		close(done)
+	}()
+
+	events := []string{}
+	for {
+		select {
+		case termination := <-pod.ContainerTermination:
+			logs := []string{}
+			for _, line := range termination.LogLines {
+				logs = append(logs, line.Message)
+			}
+			events = append(events, fmt.Sprintf("termination %s: %s, logs: %v", termination.ContainerName, termination.Summary(), logs))
+		case restart := <-pod.ContainerRestart:
+			events = append(events, fmt.Sprintf("restart %s: %s", restart.ContainerName, restart.Summary()))
+		case containerError := <-pod.ContainerError:
+			events = append(events, fmt.Sprintf("error %s: %s", containerError.ContainerName, containerError.Message))
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			return events
+		}
+	}
+}
+
+// logsClientset is the fake clientset which returns logs of all pods containers made by the logs function
+type logsClientset struct {
+	*fake.Clientset
+	logs func(opts *corev1.PodLogOptions) string
+}
+
+// newLogsClientset returns the fake clientset which returns the same logs for all pods containers
+func newLogsClientset(logs string) kubernetes.Interface {
+	return newLogsFuncClientset(func(*corev1.PodLogOptions) string { return logs })
+}
+
+func newLogsFuncClientset(logs func(opts *corev1.PodLogOptions) string) kubernetes.Interface {
+	return &logsClientset{Clientset: fake.NewSimpleClientset(), logs: logs}
+}
+
+func (c *logsClientset) CoreV1() corev1client.CoreV1Interface {
+	return &logsCoreV1{CoreV1Interface: c.Clientset.CoreV1(), logs: c.logs}
+}
+
+type logsCoreV1 struct {
+	corev1client.CoreV1Interface
+	logs func(opts *corev1.PodLogOptions) string
+}
+
+func (c *logsCoreV1) Pods(namespace string) corev1client.PodInterface {
+	return &logsPods{PodInterface: c.CoreV1Interface.Pods(namespace), logs: c.logs}
+}
+
+type logsPods struct {
+	corev1client.PodInterface
+	logs func(opts *corev1.PodLogOptions) string
+}
+
+func (c *logsPods) GetLogs(name string, opts *corev1.PodLogOptions) *rest.Request {
+	logs := c.logs(opts)
+	client := &restfake.RESTClient{
+		NegotiatedSerializer: scheme.Codecs,
+		Client: restfake.CreateHTTPClient(func(*http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(logs))}, nil
+		}),
+	}
+	return client.Get()
+}
@@ -5,13 +5,14 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"strings"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -28,6 +29,12 @@ type PodStatus struct {
 
 	IsFailed     bool
 	FailedReason string
+
+	// ProbeFailures are the probe failures aggregated from Unhealthy events of the pod by container and probe type
+	ProbeFailures []ProbeFailure
+
+	// Annotations are annotations of the tracked object
+	Annotations map[string]string
 }
 
 func NewPodStatus(isFailed bool, failedReason string, kubeStatus corev1.PodStatus) PodStatus {
@@ -38,9 +45,19 @@ func NewPodStatus(isFailed bool, failedReason string, kubeStatus corev1.PodStatu
 	}
 }
 
+func (pod *Tracker) newPodStatus() PodStatus {
+	status := NewPodStatus(pod.State == "Failed", pod.failedReason, pod.lastObject.Status)
+	status.ProbeFailures = pod.probeFailuresList()
+	status.Annotations = pod.lastObject.Annotations
+	return status
+}
+
 type ContainerError struct {
 	Message       string
 	ContainerName string
+	// Reason is the container waiting or termination reason or the pod status reason, e.g. CrashLoopBackOff
+	Reason string
+	Type   ContainerErrorType
 }
 
 type ContainerLogChunk struct {
@@ -68,25 +85,59 @@ type Tracker struct {
 	Ready             chan struct{}
 	ContainerLogChunk chan *ContainerLogChunk
 	ContainerError    chan ContainerError
-	StatusReport      chan PodStatus
+	// ContainerTermination receives details of crashed container instances
+	ContainerTermination chan ContainerTermination
+	// Unschedulable receives the scheduling failure when the pod cannot be scheduled and when the reasons change
+	Unschedulable chan SchedulingFailure
+	// ContainerRestart receives restarts of containers noticed since the start of tracking
+	ContainerRestart chan ContainerRestart
+	StatusReport     chan PodStatus
 
 	State                           tracker.TrackerState
 	ContainerTrackerStates          map[string]tracker.TrackerState
 	ProcessedContainerLogTimestamps map[string]time.Time
 	TrackedContainers               []string
 	LogsFromTime                    time.Time
+	// FatalContainerReasons are reasons reported as container errors, DefaultFatalContainerReasons are used when nil
+	FatalContainerReasons []string
 
 	lastObject   *corev1.Pod
 	failedReason string
+	// reportedContainerTerminations are container instances which termination has been reported,
+	// seenContainerTerminations are containers which termination has been checked at least once
+	reportedContainerTerminations map[string]bool
+	seenContainerTerminations     map[string]bool
+	// erroredContainerTerminations are container instances which termination has been reported as ContainerError
+	erroredContainerTerminations map[string]bool
+	// reportedPodStatusReason is the fatal pod status reason which has been reported
+	reportedPodStatusReason string
+	// lastSchedulingFailureMessage is the last reported scheduler message
+	lastSchedulingFailureMessage string
+	// initialContainerRestartCounts and containerRestartCounts are the first seen and the last reported
+	// restart counts of containers
+	initialContainerRestartCounts map[string]int32
+	containerRestartCounts        map[string]int32
+	// probeFailures are aggregated probe failures by container/probe type,
+	// probeEventCounts are the last counted Count of Unhealthy events
+	probeFailures    map[string]ProbeFailure
+	probeEventCounts map[types.UID]int32
+
+	// containersMux guards ContainerTrackerStates and ProcessedContainerLogTimestamps,
+	// which are shared with containers trackers goroutines
+	containersMux sync.Mutex
 
 	objectAdded    chan *corev1.Pod
 	objectModified chan *corev1.Pod
 	objectDeleted  chan *corev1.Pod
 	objectFailed   chan string
+	objectEvent    chan *corev1.Event
 	containerDone  chan string
 	errors         chan error
 }
 
+// containerLogsReconnectInterval is the delay before reconnecting to the ended container logs stream
+const containerLogsReconnectInterval = 2 * time.Second
+
 func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Interface) *Tracker {
 	return &Tracker{
 		Tracker: tracker.Tracker{
@@ -100,12 +151,15 @@ func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Int
 		Added:     make(chan struct{}, 0),
 		Succeeded: make(chan struct{}, 0),
 
-		Failed:            make(chan string, 1),
-		EventMsg:          make(chan string, 1),
-		Ready:             make(chan struct{}, 0),
-		ContainerError:    make(chan ContainerError, 0),
-		ContainerLogChunk: make(chan *ContainerLogChunk, 1000),
-		StatusReport:      make(chan PodStatus, 100),
+		Failed:               make(chan string, 1),
+		EventMsg:             make(chan string, 1),
+		Ready:                make(chan struct{}, 0),
+		ContainerError:       make(chan ContainerError, 0),
+		ContainerTermination: make(chan ContainerTermination, 0),
+		Unschedulable:        make(chan SchedulingFailure, 0),
+		ContainerRestart:     make(chan ContainerRestart, 0),
+		ContainerLogChunk:    make(chan *ContainerLogChunk, 1000),
+		StatusReport:         make(chan PodStatus, 100),
 
 		State:                           tracker.Initial,
 		ContainerTrackerStates:          make(map[string]tracker.TrackerState),
@@ -113,10 +167,19 @@ func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Int
 		TrackedContainers:               make([]string, 0),
 		LogsFromTime:                    time.Time{},
 
+		reportedContainerTerminations: make(map[string]bool),
+		seenContainerTerminations:     make(map[string]bool),
+		erroredContainerTerminations:  make(map[string]bool),
+		initialContainerRestartCounts: make(map[string]int32),
+		containerRestartCounts:        make(map[string]int32),
+		probeFailures:                 make(map[string]ProbeFailure),
+		probeEventCounts:              make(map[types.UID]int32),
+
 		objectAdded:    make(chan *corev1.Pod, 0),
 		objectModified: make(chan *corev1.Pod, 0),
 		objectDeleted:  make(chan *corev1.Pod, 0),
 		objectFailed:   make(chan string, 1),
+		objectEvent:    make(chan *corev1.Event, 0),
 		errors:         make(chan error, 0),
 		containerDone:  make(chan string, 10),
 	}
@@ -149,14 +212,20 @@ func (pod *Tracker) Start() error {
 
 		case object := <-pod.objectAdded:
 			pod.lastObject = object
-			pod.StatusReport <- NewPodStatus(pod.State == "Failed", pod.failedReason, pod.lastObject.Status)
+			select {
+			case pod.StatusReport <- pod.newPodStatus():
+			case <-pod.Context.Done():
+			}
 
 			pod.runEventsInformer()
 
 			switch pod.State {
 			case tracker.Initial:
 				pod.State = tracker.ResourceAdded
-				pod.Added <- struct{}{}
+				select {
+				case pod.Added <- struct{}{}:
+				case <-pod.Context.Done():
+				}
 
 				err := pod.runContainersTrackers(object)
 				if err != nil {
@@ -174,7 +243,10 @@ func (pod *Tracker) Start() error {
 
 		case object := <-pod.objectModified:
 			pod.lastObject = object
-			pod.StatusReport <- NewPodStatus(pod.State == "Failed", pod.failedReason, pod.lastObject.Status)
+			select {
+			case pod.StatusReport <- pod.newPodStatus():
+			case <-pod.Context.Done():
+			}
 
 			done, err := pod.handlePodState(object)
 			if err != nil {
@@ -186,8 +258,12 @@ func (pod *Tracker) Start() error {
 
 		case <-pod.objectDeleted:
 			pod.lastObject = nil
-			pod.StatusReport <- PodStatus{}
+			select {
+			case pod.StatusReport <- PodStatus{}:
+			case <-pod.Context.Done():
+			}
 
+			pod.containersMux.Lock()
 			keys := []string{}
 			for k := range pod.ContainerTrackerStates {
 				keys = append(keys, k)
@@ -195,6 +271,7 @@ func (pod *Tracker) Start() error {
 			for _, k := range keys {
 				pod.ContainerTrackerStates[k] = tracker.ContainerTrackerDone
 			}
+			pod.containersMux.Unlock()
 
 			if debug.Debug() {
 				fmt.Printf("Pod `%s` resource gone: stop tracking\n", pod.ResourceName)
@@ -207,9 +284,23 @@ func (pod *Tracker) Start() error {
 			pod.failedReason = reason
 
 			if pod.lastObject != nil {
-				pod.StatusReport <- NewPodStatus(pod.State == "Failed", pod.failedReason, pod.lastObject.Status)
+				select {
+				case pod.StatusReport <- pod.newPodStatus():
+				case <-pod.Context.Done():
+				}
+			}
+			select {
+			case pod.Failed <- reason:
+			case <-pod.Context.Done():
+			}
+
+		case event := <-pod.objectEvent:
+			switch event.Reason {
+			case "FailedScheduling":
+				pod.handleSchedulingFailure(ParseSchedulingFailureMessage(event.Message))
+			case "Unhealthy":
+				pod.handleProbeEvent(event)
 			}
-			pod.Failed <- reason
 
 		case <-pod.Context.Done():
 			return tracker.ErrTrackInterrupted
@@ -226,18 +317,40 @@ func (pod *Tracker) handlePodState(object *corev1.Pod) (done bool, err error) {
 		return false, err
 	}
 
+	pod.handlePodStatusReason(object)
+	pod.handlePodScheduling(object)
+
 	for _, cond := range object.Status.Conditions {
 		if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
-			pod.Ready <- struct{}{}
+			select {
+			case pod.Ready <- struct{}{}:
+			case <-pod.Context.Done():
+			}
 		}
 	}
 
 	if len(pod.TrackedContainers) == 0 {
 		if object.Status.Phase == corev1.PodSucceeded {
-			pod.Succeeded <- struct{}{}
+			select {
+			case pod.Succeeded <- struct{}{}:
+			case <-pod.Context.Done():
+			}
 			done = true
 		} else if object.Status.Phase == corev1.PodFailed {
-			pod.Failed <- "pod is in a Failed phase"
+			// the failure has already been reported as the pod status reason error
+			if object.Status.Reason != "" && object.Status.Reason == pod.reportedPodStatusReason {
+				return true, nil
+			}
+
+			reason := "pod is in a Failed phase"
+			if object.Status.Reason != "" {
+				reason += fmt.Sprintf(": %s: %s", object.Status.Reason, object.Status.Message)
+			}
+
+			select {
+			case pod.Failed <- reason:
+			case <-pod.Context.Done():
+			}
 			done = true
 		}
 	}
@@ -254,26 +367,53 @@ func (pod *Tracker) handleContainersState(object *corev1.Pod) error {
 		allContainerStatuses = append(allContainerStatuses, cs)
 	}
 
+	initContainers := make(map[string]bool)
+	for _, cs := range object.Status.InitContainerStatuses {
+		initContainers[cs.Name] = true
+	}
+
 	for _, cs := range allContainerStatuses {
-		oldState := pod.ContainerTrackerStates[cs.Name]
-
-		if cs.State.Waiting != nil {
-			switch cs.State.Waiting.Reason {
-			case "ImagePullBackOff", "ErrImagePull", "CrashLoopBackOff":
-				pod.ContainerError <- ContainerError{
-					ContainerName: cs.Name,
-					Message:       fmt.Sprintf("%s: %s", cs.State.Waiting.Reason, cs.State.Waiting.Message),
+		pod.handleContainerTermination(cs)
+		pod.handleContainerRestart(cs)
+
+		oldState := pod.containerTrackerState(cs.Name)
+		if oldState == tracker.ContainerTrackerDone {
+			continue
+		}
+		newState := oldState
+
+		if cs.State.Waiting != nil && pod.isFatalContainerReason(cs.State.Waiting.Reason) {
+			terminated := cs.LastTerminationState.Terminated
+
+			// CrashLoopBackOff after the termination which has already been reported as the error, e.g. OOMKilled,
+			// is the same crash
+			if terminated == nil || !pod.erroredContainerTerminations[containerTerminationKey(cs, terminated)] {
+				message := fmt.Sprintf("%s: %s", cs.State.Waiting.Reason, cs.State.Waiting.Message)
+				if terminated != nil {
+					message += fmt.Sprintf(" (last termination: %s)", newContainerTermination(cs, terminated).Summary())
 				}
+
+				pod.sendContainerError(newContainerError(cs.Name, cs.State.Waiting.Reason, message))
 			}
 		}
 
-		if cs.State.Running != nil || cs.State.Terminated != nil {
-			pod.ContainerTrackerStates[cs.Name] = tracker.FollowingContainerLogs
+		if cs.State.Running != nil {
+			newState = tracker.FollowingContainerLogs
+		} else if cs.State.Terminated != nil {
+			// Logs of the next container instance are followed after restart,
+			// logs of the container which will not be restarted are followed until the end of stream
+			if isContainerRestartable(object, cs.State.Terminated, initContainers[cs.Name]) {
+				newState = tracker.FollowingContainerLogs
+			} else {
+				newState = tracker.FollowingTerminatedContainerLogs
+			}
 		}
 
-		if oldState != pod.ContainerTrackerStates[cs.Name] {
+		if oldState != newState {
+			pod.setContainerTrackerState(cs.Name, newState)
+
 			if debug.Debug() {
-				fmt.Printf("Pod `%s` container `%s` state changed %#v -> %#v\n", pod.ResourceName, cs.Name, oldState, pod.ContainerTrackerStates[cs.Name])
+				fmt.Printf("Pod `%s` container `%s` state changed %#v -> %#v\n", pod.ResourceName, cs.Name, oldState, newState)
 			}
 		}
 	}
@@ -282,21 +422,29 @@ func (pod *Tracker) handleContainersState(object *corev1.Pod) error {
 }
 
 func (pod *Tracker) followContainerLogs(containerName string) error {
+	// Stream is continued from the last processed line after reconnect or container restart
+	processedTimestamp := pod.processedContainerLogTimestamp(containerName)
+	sinceTime := pod.LogsFromTime
+	if processedTimestamp.After(sinceTime) {
+		sinceTime = processedTimestamp
+	}
+	lastTimestamp := processedTimestamp
+
 	logOpts := &corev1.PodLogOptions{
 		Container:  containerName,
 		Timestamps: true,
 		Follow:     true,
 	}
-	if !pod.LogsFromTime.IsZero() {
+	if !sinceTime.IsZero() {
 		logOpts.SinceTime = &metav1.Time{
-			Time: pod.LogsFromTime,
+			Time: sinceTime,
 		}
 	}
 	req := pod.Kube.CoreV1().
 		Pods(pod.Namespace).
 		GetLogs(pod.ResourceName, logOpts)
 
-	readCloser, err := req.Stream()
+	readCloser, err := req.Context(pod.Context).Stream()
 	if err != nil {
 		return err
 	}
@@ -317,9 +465,16 @@ func (pod *Tracker) followContainerLogs(containerName string) error {
 					line := string(lineBuf)
 					lineBuf = lineBuf[:0]
 
-					lineParts := strings.SplitN(line, " ", 2)
-					if len(lineParts) == 2 {
-						chunkLines = append(chunkLines, display.LogLine{Timestamp: lineParts[0], Message: lineParts[1]})
+					if logLine, ok := parseLogLine(line); ok {
+						// SinceTime has seconds precision, so lines already processed before reconnect are skipped
+						if timestamp, err := time.Parse(time.RFC3339Nano, logLine.Timestamp); err == nil {
+							if !processedTimestamp.IsZero() && !timestamp.After(processedTimestamp) {
+								continue
+							}
+							lastTimestamp = timestamp
+						}
+
+						chunkLines = append(chunkLines, logLine)
 					}
 
 					continue
@@ -328,9 +483,16 @@ func (pod *Tracker) followContainerLogs(containerName string) error {
 				lineBuf = append(lineBuf, bt)
 			}
 
-			pod.ContainerLogChunk <- &ContainerLogChunk{
-				ContainerName: containerName,
-				LogLines:      chunkLines,
+			if len(chunkLines) > 0 {
+				pod.setProcessedContainerLogTimestamp(containerName, lastTimestamp)
+
+				select {
+				case pod.ContainerLogChunk <- &ContainerLogChunk{
+					ContainerName: containerName,
+					LogLines:      chunkLines,
+				}:
+				case <-pod.Context.Done():
+				}
 			}
 		}
 
@@ -352,24 +514,41 @@ func (pod *Tracker) followContainerLogs(containerName string) error {
 	return nil
 }
 
+// trackContainer follows logs of the container until it is terminated and will not be restarted.
+// Logs stream is reconnected when it is dropped or when the container is restarted.
 func (pod *Tracker) trackContainer(containerName string) error {
 	ticker := time.NewTicker(200 * time.Millisecond)
 	defer ticker.Stop()
 
+	var reconnectAt time.Time
+
 	for {
 		select {
 		case <-ticker.C:
-			state := pod.ContainerTrackerStates[containerName]
+			state := pod.containerTrackerState(containerName)
 
 			switch state {
-			case tracker.FollowingContainerLogs:
+			case tracker.FollowingContainerLogs, tracker.FollowingTerminatedContainerLogs:
+				if time.Now().Before(reconnectAt) {
+					continue
+				}
+
 				err := pod.followContainerLogs(containerName)
 				if err != nil {
 					if debug.Debug() {
 						fmt.Fprintf(os.Stderr, "Pod `%s` Container `%s` logs streaming error: %s\n", pod.ResourceName, containerName, err)
 					}
 				}
-				return nil
+
+				if state == tracker.FollowingTerminatedContainerLogs {
+					pod.setContainerTrackerState(containerName, tracker.ContainerTrackerDone)
+					return nil
+				}
+
+				if debug.Debug() {
+					fmt.Printf("Pod `%s` Container `%s` logs stream ended, reconnecting in %s\n", pod.ResourceName, containerName, containerLogsReconnectInterval)
+				}
+				reconnectAt = time.Now().Add(containerLogsReconnectInterval)
 			case tracker.Initial:
 			case tracker.ContainerTrackerDone:
 				return nil
@@ -383,6 +562,47 @@ func (pod *Tracker) trackContainer(containerName string) error {
 	}
 }
 
+func (pod *Tracker) containerTrackerState(containerName string) tracker.TrackerState {
+	pod.containersMux.Lock()
+	defer pod.containersMux.Unlock()
+	return pod.ContainerTrackerStates[containerName]
+}
+
+func (pod *Tracker) setContainerTrackerState(containerName string, state tracker.TrackerState) {
+	pod.containersMux.Lock()
+	defer pod.containersMux.Unlock()
+	pod.ContainerTrackerStates[containerName] = state
+}
+
+func (pod *Tracker) processedContainerLogTimestamp(containerName string) time.Time {
+	pod.containersMux.Lock()
+	defer pod.containersMux.Unlock()
+	return pod.ProcessedContainerLogTimestamps[containerName]
+}
+
+func (pod *Tracker) setProcessedContainerLogTimestamp(containerName string, timestamp time.Time) {
+	pod.containersMux.Lock()
+	defer pod.containersMux.Unlock()
+	pod.ProcessedContainerLogTimestamps[containerName] = timestamp
+}
+
+// isContainerRestartable returns true when the terminated container will be started again according to pod restart policy
+func isContainerRestartable(object *corev1.Pod, terminated *corev1.ContainerStateTerminated, isInitContainer bool) bool {
+	if object.Status.Phase == corev1.PodSucceeded || object.Status.Phase == corev1.PodFailed {
+		return false
+	}
+
+	switch object.Spec.RestartPolicy {
+	case corev1.RestartPolicyAlways:
+		// init containers are not started again after successful completion
+		return !isInitContainer || terminated.ExitCode != 0
+	case corev1.RestartPolicyOnFailure:
+		return terminated.ExitCode != 0
+	default:
+		return false
+	}
+}
+
 func (pod *Tracker) runContainersTrackers(object *corev1.Pod) error {
 	allContainersNames := make([]string, 0)
 	for _, containerConf := range object.Spec.InitContainers {
@@ -394,7 +614,7 @@ func (pod *Tracker) runContainersTrackers(object *corev1.Pod) error {
 	for i := range allContainersNames {
 		containerName := allContainersNames[i]
 
-		pod.ContainerTrackerStates[containerName] = tracker.Initial
+		pod.setContainerTrackerState(containerName, tracker.Initial)
 		pod.TrackedContainers = append(pod.TrackedContainers, containerName)
 
 		go func() {
@@ -404,14 +624,20 @@ func (pod *Tracker) runContainersTrackers(object *corev1.Pod) error {
 
 			err := pod.trackContainer(containerName)
 			if err != nil {
-				pod.errors <- err
+				select {
+				case pod.errors <- err:
+				case <-pod.Context.Done():
+				}
 			}
 
 			if debug.Debug() {
 				fmt.Printf("Done tracking Pod's `%s` container `%s`\n", pod.ResourceName, containerName)
 			}
 
-			pod.containerDone <- containerName
+			select {
+			case pod.containerDone <- containerName:
+			case <-pod.Context.Done():
+			}
 		}()
 	}
 
@@ -449,20 +675,35 @@ func (pod *Tracker) runInformer() error {
 			}
 
 			if e.Type == watch.Added {
-				pod.objectAdded <- object
+				select {
+				case pod.objectAdded <- object:
+				case <-pod.Context.Done():
+				}
 			} else if e.Type == watch.Modified {
-				pod.objectModified <- object
+				select {
+				case pod.objectModified <- object:
+				case <-pod.Context.Done():
+				}
 			} else if e.Type == watch.Deleted {
-				pod.objectDeleted <- object
+				select {
+				case pod.objectDeleted <- object:
+				case <-pod.Context.Done():
+				}
 			} else if e.Type == watch.Error {
-				pod.errors <- fmt.Errorf("Pod %s error: %v", pod.ResourceName, e.Object)
+				select {
+				case pod.errors <- fmt.Errorf("Pod %s error: %v", pod.ResourceName, e.Object):
+				case <-pod.Context.Done():
+				}
 			}
 
 			return false, nil
 		})
 
 		if err != nil {
-			pod.errors <- err
+			select {
+			case pod.errors <- err:
+			case <-pod.Context.Done():
+			}
 		}
 
 		if debug.Debug() {
@@ -481,7 +722,10 @@ func (pod *Tracker) runEventsInformer() {
 
 	eventInformer := event.NewEventInformer(&pod.Tracker, pod.lastObject)
 	eventInformer.WithChannels(pod.EventMsg, pod.objectFailed, pod.errors)
+	eventInformer.WithObjects(pod.objectEvent, "FailedScheduling")
 	eventInformer.Run()
 
+	pod.handleInitialProbeEvents(eventInformer.InitialEvents)
+
 	return
 }
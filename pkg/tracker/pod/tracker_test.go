@@ -0,0 +1,70 @@
+package pod
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestFollowContainerLogsReconnect(t *testing.T) {
+	logs := "2019-01-01T00:00:01.1Z first\n2019-01-01T00:00:01.2Z second\n"
+	sinceTimes := []time.Time{}
+	pod := NewTracker(context.Background(), "mypod", "myns", newLogsFuncClientset(func(opts *corev1.PodLogOptions) string {
+		sinceTime := time.Time{}
+		if opts.SinceTime != nil {
+			sinceTime = opts.SinceTime.Time
+		}
+		sinceTimes = append(sinceTimes, sinceTime)
+		return logs
+	}))
+
+	if messages := followContainerLogs(t, pod, "main"); !reflect.DeepEqual(messages, []string{"first", "second"}) {
+		t.Fatalf("expected all lines, got %q", messages)
+	}
+
+	// Stream with SinceTime of seconds precision repeats processed lines of the same second
+	logs += "2019-01-01T00:00:01.3Z third\n2019-01-01T00:00:02Z fourth\n"
+	if messages := followContainerLogs(t, pod, "main"); !reflect.DeepEqual(messages, []string{"third", "fourth"}) {
+		t.Errorf("expected only new lines after reconnect, got %q", messages)
+	}
+
+	if messages := followContainerLogs(t, pod, "sidecar"); len(messages) != 4 {
+		t.Errorf("expected all lines of other container, got %q", messages)
+	}
+
+	expectedSinceTimes := []time.Time{
+		{},
+		time.Date(2019, 1, 1, 0, 0, 1, 200000000, time.UTC),
+		{},
+	}
+	if len(sinceTimes) != len(expectedSinceTimes) {
+		t.Fatalf("expected %d logs requests, got %v", len(expectedSinceTimes), sinceTimes)
+	}
+	for i := range expectedSinceTimes {
+		if !sinceTimes[i].Equal(expectedSinceTimes[i]) {
+			t.Errorf("expected request %d since %s, got %s", i, expectedSinceTimes[i], sinceTimes[i])
+		}
+	}
+}
+
+// followContainerLogs reads the container logs stream till the end and returns messages of sent log lines
+func followContainerLogs(t *testing.T, pod *Tracker, containerName string) []string {
+	if err := pod.followContainerLogs(containerName); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	messages := []string{}
+	for {
+		select {
+		case chunk := <-pod.ContainerLogChunk:
+			for _, line := range chunk.LogLines {
+				messages = append(messages, line.Message)
+			}
+		default:
+			return messages
+		}
+	}
+}
@@ -37,6 +37,21 @@ type ReplicaSetPodError struct {
 	ReplicaSet ReplicaSet
 }
 
+type ReplicaSetPodContainerTermination struct {
+	pod.PodContainerTermination
+	ReplicaSet ReplicaSet
+}
+
+type ReplicaSetPodSchedulingFailure struct {
+	pod.PodSchedulingFailure
+	ReplicaSet ReplicaSet
+}
+
+type ReplicaSetPodContainerRestart struct {
+	pod.PodContainerRestart
+	ReplicaSet ReplicaSet
+}
+
 // ReplicaSetInformer monitor ReplicaSet events to use with controllers (Deployment, StatefulSet, DaemonSet)
 type ReplicaSetInformer struct {
 	tracker.Tracker
@@ -118,18 +133,30 @@ func (r *ReplicaSetInformer) Run() {
 
 			switch e.Type {
 			case watch.Added:
-				r.ReplicaSetAdded <- object
+				select {
+				case r.ReplicaSetAdded <- object:
+				case <-r.Context.Done():
+				}
 			case watch.Modified:
-				r.ReplicaSetModified <- object
+				select {
+				case r.ReplicaSetModified <- object:
+				case <-r.Context.Done():
+				}
 			case watch.Deleted:
-				r.ReplicaSetDeleted <- object
+				select {
+				case r.ReplicaSetDeleted <- object:
+				case <-r.Context.Done():
+				}
 			}
 
 			return false, nil
 		})
 
 		if err != nil {
-			r.Errors <- err
+			select {
+			case r.Errors <- err:
+			case <-r.Context.Done():
+			}
 		}
 
 		if debug.Debug() {
@@ -26,11 +26,15 @@ import (
 type StatefulSetStatus struct {
 	appsv1.StatefulSetStatus
 	Pods map[string]pod.PodStatus
+
+	// Annotations are annotations of the tracked object
+	Annotations map[string]string
 }
 
 type Tracker struct {
 	tracker.Tracker
-	LogsFromTime time.Time
+	LogsFromTime          time.Time
+	FatalContainerReasons []string
 
 	State                  string
 	Conditions             []string
@@ -47,6 +51,10 @@ type Tracker struct {
 	PodError     chan replicaset.ReplicaSetPodError
 	StatusReport chan StatefulSetStatus
 
+	PodContainerTermination chan replicaset.ReplicaSetPodContainerTermination
+	PodUnschedulable        chan replicaset.ReplicaSetPodSchedulingFailure
+	PodContainerRestart     chan replicaset.ReplicaSetPodContainerRestart
+
 	resourceAdded     chan *appsv1.StatefulSet
 	resourceModified  chan *appsv1.StatefulSet
 	resourceDeleted   chan *appsv1.StatefulSet
@@ -72,7 +80,8 @@ func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Int
 			Context:          ctx,
 		},
 
-		LogsFromTime: opts.LogsFromTime,
+		LogsFromTime:          opts.LogsFromTime,
+		FatalContainerReasons: opts.FatalContainerReasons,
 
 		Added:        make(chan bool, 0),
 		Ready:        make(chan bool, 1),
@@ -83,6 +92,10 @@ func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Int
 		PodError:     make(chan replicaset.ReplicaSetPodError, 0),
 		StatusReport: make(chan StatefulSetStatus, 100),
 
+		PodContainerTermination: make(chan replicaset.ReplicaSetPodContainerTermination, 0),
+		PodUnschedulable:        make(chan replicaset.ReplicaSetPodSchedulingFailure, 0),
+		PodContainerRestart:     make(chan replicaset.ReplicaSetPodContainerRestart, 0),
+
 		podStatuses: make(map[string]pod.PodStatus),
 		TrackedPods: make([]string, 0),
 
@@ -108,6 +121,12 @@ func NewStatefulSetStatus(kubeStatus appsv1.StatefulSetStatus, podsStatuses map[
 	return res
 }
 
+func (d *Tracker) newStatefulSetStatus() StatefulSetStatus {
+	status := NewStatefulSetStatus(d.lastObject.Status, d.podStatuses)
+	status.Annotations = d.lastObject.Annotations
+	return status
+}
+
 // Track starts tracking of StatefulSet rollout process.
 // watch only for one StatefulSet resource with name d.ResourceName within the namespace with name d.Namespace
 // Watcher can wait for namespace creation and then for StatefulSet creation
@@ -126,7 +145,10 @@ func (d *Tracker) Track() (err error) {
 		select {
 		case object := <-d.resourceAdded:
 			d.lastObject = object
-			d.StatusReport <- NewStatefulSetStatus(d.lastObject.Status, d.podStatuses)
+			select {
+			case d.StatusReport <- d.newStatefulSetStatus():
+			case <-d.Context.Done():
+			}
 
 			ready := d.handleStatefulSetState(object)
 			if debug.Debug() {
@@ -136,7 +158,10 @@ func (d *Tracker) Track() (err error) {
 			switch d.State {
 			case "":
 				d.State = "Started"
-				d.Added <- ready
+				select {
+				case d.Added <- ready:
+				case <-d.Context.Done():
+				}
 			}
 
 			d.runPodsInformer()
@@ -144,24 +169,39 @@ func (d *Tracker) Track() (err error) {
 
 		case object := <-d.resourceModified:
 			d.lastObject = object
-			d.StatusReport <- NewStatefulSetStatus(d.lastObject.Status, d.podStatuses)
+			select {
+			case d.StatusReport <- d.newStatefulSetStatus():
+			case <-d.Context.Done():
+			}
 
 			ready := d.handleStatefulSetState(object)
 			if ready {
 				d.FinalStatefulSetStatus = object.Status
-				d.Ready <- true
+				select {
+				case d.Ready <- true:
+				case <-d.Context.Done():
+				}
 			}
 		case <-d.resourceDeleted:
 			d.lastObject = nil
-			d.StatusReport <- StatefulSetStatus{}
+			select {
+			case d.StatusReport <- StatefulSetStatus{}:
+			case <-d.Context.Done():
+			}
 
 			d.State = "Deleted"
-			d.Failed <- "resource deleted"
+			select {
+			case d.Failed <- "resource deleted":
+			case <-d.Context.Done():
+			}
 			// TODO: This is not fail on tracker level
 
 		case reason := <-d.resourceFailed:
 			d.State = "Failed"
-			d.Failed <- reason
+			select {
+			case d.Failed <- reason:
+			case <-d.Context.Done():
+			}
 
 		case pod := <-d.podAdded:
 			if debug.Debug() {
@@ -173,7 +213,10 @@ func (d *Tracker) Track() (err error) {
 				ReplicaSet: replicaset.ReplicaSet{},
 			}
 
-			d.AddedPod <- rsPod
+			select {
+			case d.AddedPod <- rsPod:
+			case <-d.Context.Done():
+			}
 
 			err = d.runPodTracker(pod.Name)
 			if err != nil {
@@ -194,7 +237,10 @@ func (d *Tracker) Track() (err error) {
 				d.podStatuses[podName] = podStatus
 			}
 			if d.lastObject != nil {
-				d.StatusReport <- NewStatefulSetStatus(d.lastObject.Status, d.podStatuses)
+				select {
+				case d.StatusReport <- d.newStatefulSetStatus():
+				case <-d.Context.Done():
+				}
 			}
 
 		case <-d.Context.Done():
@@ -243,11 +289,20 @@ func (d *Tracker) runStatefulSetInformer() {
 
 			switch e.Type {
 			case watch.Added:
-				d.resourceAdded <- object
+				select {
+				case d.resourceAdded <- object:
+				case <-d.Context.Done():
+				}
 			case watch.Modified:
-				d.resourceModified <- object
+				select {
+				case d.resourceModified <- object:
+				case <-d.Context.Done():
+				}
 			case watch.Deleted:
-				d.resourceDeleted <- object
+				select {
+				case d.resourceDeleted <- object:
+				case <-d.Context.Done():
+				}
 			case watch.Error:
 				err := fmt.Errorf("StatefulSet error: %v", e.Object)
 				//d.errors <- err
@@ -258,7 +313,10 @@ func (d *Tracker) runStatefulSetInformer() {
 		})
 
 		if err != nil {
-			d.errors <- err
+			select {
+			case d.errors <- err:
+			case <-d.Context.Done():
+			}
 		}
 
 		if debug.Debug() {
@@ -285,10 +343,11 @@ func (d *Tracker) runPodsInformer() {
 }
 
 func (d *Tracker) runPodTracker(podName string) error {
-	errorChan := make(chan error, 0)
-	doneChan := make(chan struct{}, 0)
+	errorChan := make(chan error, 1)
+	doneChan := make(chan struct{}, 1)
 
 	podTracker := pod.NewTracker(d.Context, podName, d.Namespace, d.Kube)
+	podTracker.FatalContainerReasons = d.FatalContainerReasons
 	if !d.LogsFromTime.IsZero() {
 		podTracker.LogsFromTime = d.LogsFromTime
 	}
@@ -323,7 +382,10 @@ func (d *Tracker) runPodTracker(podName string) error {
 					ReplicaSet: replicaset.ReplicaSet{},
 				}
 
-				d.PodLogChunk <- rsChunk
+				select {
+				case d.PodLogChunk <- rsChunk:
+				case <-d.Context.Done():
+				}
 			case containerError := <-podTracker.ContainerError:
 				podError := replicaset.ReplicaSetPodError{
 					PodError: pod.PodError{
@@ -333,20 +395,76 @@ func (d *Tracker) runPodTracker(podName string) error {
 					ReplicaSet: replicaset.ReplicaSet{},
 				}
 
-				d.PodError <- podError
+				select {
+				case d.PodError <- podError:
+				case <-d.Context.Done():
+				}
+			case termination := <-podTracker.ContainerTermination:
+				rsTermination := replicaset.ReplicaSetPodContainerTermination{
+					PodContainerTermination: pod.PodContainerTermination{
+						ContainerTermination: termination,
+						PodName:              podTracker.ResourceName,
+					},
+					ReplicaSet: replicaset.ReplicaSet{},
+				}
+
+				select {
+				case d.PodContainerTermination <- rsTermination:
+				case <-d.Context.Done():
+				}
+			case failure := <-podTracker.Unschedulable:
+				rsFailure := replicaset.ReplicaSetPodSchedulingFailure{
+					PodSchedulingFailure: pod.PodSchedulingFailure{
+						SchedulingFailure: failure,
+						PodName:           podTracker.ResourceName,
+					},
+					ReplicaSet: replicaset.ReplicaSet{},
+				}
+
+				select {
+				case d.PodUnschedulable <- rsFailure:
+				case <-d.Context.Done():
+				}
+			case restart := <-podTracker.ContainerRestart:
+				rsRestart := replicaset.ReplicaSetPodContainerRestart{
+					PodContainerRestart: pod.PodContainerRestart{
+						ContainerRestart: restart,
+						PodName:          podTracker.ResourceName,
+					},
+					ReplicaSet: replicaset.ReplicaSet{},
+				}
+
+				select {
+				case d.PodContainerRestart <- rsRestart:
+				case <-d.Context.Done():
+				}
 			case msg := <-podTracker.EventMsg:
-				d.EventMsg <- fmt.Sprintf("po/%s %s", podTracker.ResourceName, msg)
+				select {
+				case d.EventMsg <- fmt.Sprintf("po/%s %s", podTracker.ResourceName, msg):
+				case <-d.Context.Done():
+				}
 			case <-podTracker.Added:
 			case <-podTracker.Succeeded:
 			case <-podTracker.Failed:
 			case <-podTracker.Ready:
 			case podStatus := <-podTracker.StatusReport:
-				d.podStatusesReport <- map[string]pod.PodStatus{podTracker.ResourceName: podStatus}
+				select {
+				case d.podStatusesReport <- map[string]pod.PodStatus{podTracker.ResourceName: podStatus}:
+				case <-d.Context.Done():
+				}
 			case err := <-errorChan:
-				d.errors <- err
+				select {
+				case d.errors <- err:
+				case <-d.Context.Done():
+				}
 				return
 			case <-doneChan:
-				d.podDone <- podTracker.ResourceName
+				select {
+				case d.podDone <- podTracker.ResourceName:
+				case <-d.Context.Done():
+				}
+				return
+			case <-d.Context.Done():
 				return
 			}
 		}
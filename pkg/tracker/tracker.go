@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"k8s.io/client-go/kubernetes"
+
+	"github.com/flant/kubedog/pkg/display"
 )
 
 var (
@@ -15,12 +17,13 @@ var (
 )
 
 const (
-	Initial                TrackerState = "Initial"
-	ResourceAdded          TrackerState = "ResourceAdded"
-	ResourceSucceeded      TrackerState = "ResourceSucceeded"
-	ResourceFailed         TrackerState = "ResourceFailed"
-	FollowingContainerLogs TrackerState = "FollowingContainerLogs"
-	ContainerTrackerDone   TrackerState = "ContainerTrackerDone"
+	Initial                          TrackerState = "Initial"
+	ResourceAdded                    TrackerState = "ResourceAdded"
+	ResourceSucceeded                TrackerState = "ResourceSucceeded"
+	ResourceFailed                   TrackerState = "ResourceFailed"
+	FollowingContainerLogs           TrackerState = "FollowingContainerLogs"
+	FollowingTerminatedContainerLogs TrackerState = "FollowingTerminatedContainerLogs"
+	ContainerTrackerDone             TrackerState = "ContainerTrackerDone"
 )
 
 type TrackerState string
@@ -38,6 +41,21 @@ type Options struct {
 	ParentContext context.Context
 	Timeout       time.Duration
 	LogsFromTime  time.Time
+
+	// FatalContainerReasons are container waiting and termination reasons and pod status reasons
+	// reported as container errors, pod.DefaultFatalContainerReasons are used when not set
+	FatalContainerReasons []string
+
+	// Printer prints messages, logs and statuses of the tracked resources, display.DefaultPrinter() is used when not set
+	Printer display.Printer
+}
+
+// GetPrinter returns Printer of the options or the default printer
+func (opts Options) GetPrinter() display.Printer {
+	if opts.Printer == nil {
+		return display.DefaultPrinter()
+	}
+	return opts.Printer
 }
 
 type ResourceError struct {
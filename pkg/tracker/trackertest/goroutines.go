@@ -0,0 +1,29 @@
+// Package trackertest provides utilities for trackers tests
+package trackertest
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+// WaitGoroutines waits for the number of goroutines to drop to the expected one,
+// the test fails with the stacks of all goroutines when it does not happen in 10 seconds
+func WaitGoroutines(t testing.TB, expected int) {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		current := runtime.NumGoroutine()
+		if current <= expected {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			buf := make([]byte, 1<<20)
+			buf = buf[:runtime.Stack(buf, true)]
+			t.Fatalf("%d goroutines leaked:\n%s", current-expected, buf)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
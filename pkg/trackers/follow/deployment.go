@@ -13,49 +13,54 @@ import (
 
 func TrackDeployment(name, namespace string, kube kubernetes.Interface, opts tracker.Options) error {
 	feed := deployment.NewFeed()
+	resource := display.Event{Kind: "deploy", Namespace: namespace, Name: name}
+	printer := opts.GetPrinter()
 
 	feed.OnAdded(func(ready bool) error {
 		if ready {
-			fmt.Fprintf(display.Out, "# deploy/%s appears to be ready\n", name)
+			printer.Message(resource.WithType(display.ReadyEvent), fmt.Sprintf("# deploy/%s appears to be ready", name))
 		} else {
-			fmt.Fprintf(display.Out, "# deploy/%s added\n", name)
+			printer.Message(resource.WithType(display.AddedEvent), fmt.Sprintf("# deploy/%s added", name))
 		}
 		return nil
 	})
 	feed.OnReady(func() error {
-		fmt.Fprintf(display.Out, "# deploy/%s become READY\n", name)
+		printer.Message(resource.WithType(display.ReadyEvent), fmt.Sprintf("# deploy/%s become READY", name))
 		return nil
 	})
 	feed.OnFailed(func(reason string) error {
-		fmt.Fprintf(display.Out, "# deploy/%s FAIL: %s\n", name, reason)
+		printer.Message(resource.WithType(display.FailedEvent).WithMessage(reason), fmt.Sprintf("# deploy/%s FAIL: %s", name, reason))
 		return nil
 	})
 	feed.OnEventMsg(func(msg string) error {
-		fmt.Fprintf(display.Out, "# deploy/%s event: %s\n", name, msg)
+		printer.Message(resource.WithType(display.EventMsgEvent).WithMessage(msg), fmt.Sprintf("# deploy/%s event: %s", name, msg))
 		return nil
 	})
 	feed.OnAddedReplicaSet(func(rs replicaset.ReplicaSet) error {
+		event := resource.WithType(display.AddedReplicaSetEvent).WithReplicaSet(rs.Name)
 		if rs.IsNew {
-			fmt.Fprintf(display.Out, "# deploy/%s new rs/%s added\n", name, rs.Name)
+			printer.Message(event, fmt.Sprintf("# deploy/%s new rs/%s added", name, rs.Name))
 		} else {
-			fmt.Fprintf(display.Out, "# deploy/%s rs/%s added\n", name, rs.Name)
+			printer.Message(event, fmt.Sprintf("# deploy/%s rs/%s added", name, rs.Name))
 		}
 
 		return nil
 	})
 	feed.OnAddedPod(func(pod replicaset.ReplicaSetPod) error {
+		event := resource.WithType(display.AddedPodEvent).WithReplicaSet(pod.ReplicaSet.Name).WithPod(pod.Name, "")
 		if pod.ReplicaSet.IsNew {
-			fmt.Fprintf(display.Out, "# deploy/%s rs/%s(new) po/%s added\n", name, pod.ReplicaSet.Name, pod.Name)
+			printer.Message(event, fmt.Sprintf("# deploy/%s rs/%s(new) po/%s added", name, pod.ReplicaSet.Name, pod.Name))
 		} else {
-			fmt.Fprintf(display.Out, "# deploy/%s rs/%s po/%s added\n", name, pod.ReplicaSet.Name, pod.Name)
+			printer.Message(event, fmt.Sprintf("# deploy/%s rs/%s po/%s added", name, pod.ReplicaSet.Name, pod.Name))
 		}
 		return nil
 	})
 	feed.OnPodError(func(podError replicaset.ReplicaSetPodError) error {
+		event := resource.WithType(display.PodErrorEvent).WithReplicaSet(podError.ReplicaSet.Name).WithPod(podError.PodName, podError.ContainerName).WithMessage(podError.Message)
 		if podError.ReplicaSet.IsNew {
-			fmt.Fprintf(display.Out, "# deploy/%s rs/%s(new) po/%s %s error: %s\n", name, podError.ReplicaSet.Name, podError.PodName, podError.ContainerName, podError.Message)
+			printer.Message(event, fmt.Sprintf("# deploy/%s rs/%s(new) po/%s %s error: %s", name, podError.ReplicaSet.Name, podError.PodName, podError.ContainerName, podError.Message))
 		} else {
-			fmt.Fprintf(display.Out, "# deploy/%s rs/%s po/%s %s error: %s\n", name, podError.ReplicaSet.Name, podError.PodName, podError.ContainerName, podError.Message)
+			printer.Message(event, fmt.Sprintf("# deploy/%s rs/%s po/%s %s error: %s", name, podError.ReplicaSet.Name, podError.PodName, podError.ContainerName, podError.Message))
 		}
 		return nil
 	})
@@ -66,7 +71,35 @@ func TrackDeployment(name, namespace string, kube kubernetes.Interface, opts tra
 		} else {
 			header = fmt.Sprintf("deploy/%s rs/%s po/%s %s", name, chunk.ReplicaSet.Name, chunk.PodName, chunk.ContainerName)
 		}
-		display.OutputLogLines(header, chunk.LogLines)
+		printer.LogLines(resource.WithReplicaSet(chunk.ReplicaSet.Name).WithPod(chunk.PodName, chunk.ContainerName), header, chunk.LogLines)
+		return nil
+	})
+	feed.OnPodContainerTermination(func(termination replicaset.ReplicaSetPodContainerTermination) error {
+		event := resource.WithType(display.ContainerTerminatedEvent).WithReplicaSet(termination.ReplicaSet.Name).WithPod(termination.PodName, termination.ContainerName).WithMessage(termination.Summary())
+		printer.Message(event, fmt.Sprintf("# deploy/%s po/%s %s terminated: %s", name, termination.PodName, termination.ContainerName, termination.Summary()))
+		if len(termination.LogLines) > 0 {
+			header := ""
+			if termination.ReplicaSet.IsNew {
+				header = fmt.Sprintf("deploy/%s rs/%s(new) po/%s %s (terminated)", name, termination.ReplicaSet.Name, termination.PodName, termination.ContainerName)
+			} else {
+				header = fmt.Sprintf("deploy/%s rs/%s po/%s %s (terminated)", name, termination.ReplicaSet.Name, termination.PodName, termination.ContainerName)
+			}
+			printer.LogLines(resource.WithReplicaSet(termination.ReplicaSet.Name).WithPod(termination.PodName, termination.ContainerName), header, termination.LogLines)
+		}
+		return nil
+	})
+	feed.OnPodUnschedulable(func(failure replicaset.ReplicaSetPodSchedulingFailure) error {
+		event := resource.WithType(display.UnschedulableEvent).WithReplicaSet(failure.ReplicaSet.Name).WithPod(failure.PodName, "").WithMessage(failure.Message)
+		printer.Message(event, fmt.Sprintf("# deploy/%s po/%s unschedulable: %s", name, failure.PodName, failure.Summary()))
+		return nil
+	})
+	feed.OnPodContainerRestart(func(restart replicaset.ReplicaSetPodContainerRestart) error {
+		event := resource.WithType(display.ContainerRestartedEvent).WithReplicaSet(restart.ReplicaSet.Name).WithPod(restart.PodName, restart.ContainerName).WithMessage(restart.Summary())
+		printer.Message(event, fmt.Sprintf("# deploy/%s po/%s %s restarted: %s", name, restart.PodName, restart.ContainerName, restart.Summary()))
+		return nil
+	})
+	feed.OnStatusReport(func(status deployment.DeploymentStatus) error {
+		printer.ResourceStatus(resource.WithType(display.StatusReportEvent).WithStatus(status))
 		return nil
 	})
 
@@ -14,34 +14,60 @@ import (
 
 func TrackJob(name, namespace string, kube kubernetes.Interface, opts tracker.Options) error {
 	feed := job.NewFeed()
+	resource := display.Event{Kind: "job", Namespace: namespace, Name: name}
+	printer := opts.GetPrinter()
 
 	feed.OnAdded(func() error {
-		fmt.Fprintf(display.Out, "# job/%s added\n", name)
+		printer.Message(resource.WithType(display.AddedEvent), fmt.Sprintf("# job/%s added", name))
 		return nil
 	})
 	feed.OnSucceeded(func() error {
-		fmt.Fprintf(display.Out, "# job/%s succeeded\n", name)
+		printer.Message(resource.WithType(display.SucceededEvent), fmt.Sprintf("# job/%s succeeded", name))
 		return nil
 	})
 	feed.OnFailed(func(reason string) error {
-		fmt.Fprintf(display.Out, "# job/%s FAIL: %s\n", name, reason)
+		printer.Message(resource.WithType(display.FailedEvent).WithMessage(reason), fmt.Sprintf("# job/%s FAIL: %s", name, reason))
 		return nil
 	})
 	feed.OnEventMsg(func(msg string) error {
-		fmt.Fprintf(display.Out, "# job/%s event: %s\n", name, msg)
+		printer.Message(resource.WithType(display.EventMsgEvent).WithMessage(msg), fmt.Sprintf("# job/%s event: %s", name, msg))
 		return nil
 	})
 	feed.OnAddedPod(func(podName string) error {
-		fmt.Fprintf(display.Out, "# job/%s po/%s added\n", name, podName)
+		printer.Message(resource.WithType(display.AddedPodEvent).WithPod(podName, ""), fmt.Sprintf("# job/%s po/%s added", name, podName))
 		return nil
 	})
 	feed.OnPodError(func(podError pod.PodError) error {
-		fmt.Fprintf(display.Out, "# job/%s po/%s %s error: %s\n", name, podError.PodName, podError.ContainerName, podError.Message)
+		event := resource.WithType(display.PodErrorEvent).WithPod(podError.PodName, podError.ContainerName).WithMessage(podError.Message)
+		printer.Message(event, fmt.Sprintf("# job/%s po/%s %s error: %s", name, podError.PodName, podError.ContainerName, podError.Message))
 		return nil
 	})
 	feed.OnPodLogChunk(func(chunk *pod.PodLogChunk) error {
 		header := fmt.Sprintf("po/%s %s", chunk.PodName, chunk.ContainerName)
-		display.OutputLogLines(header, chunk.LogLines)
+		printer.LogLines(resource.WithPod(chunk.PodName, chunk.ContainerName), header, chunk.LogLines)
+		return nil
+	})
+	feed.OnPodContainerTermination(func(termination pod.PodContainerTermination) error {
+		event := resource.WithType(display.ContainerTerminatedEvent).WithPod(termination.PodName, termination.ContainerName).WithMessage(termination.Summary())
+		printer.Message(event, fmt.Sprintf("# job/%s po/%s %s terminated: %s", name, termination.PodName, termination.ContainerName, termination.Summary()))
+		if len(termination.LogLines) > 0 {
+			header := fmt.Sprintf("po/%s %s (terminated)", termination.PodName, termination.ContainerName)
+			printer.LogLines(resource.WithPod(termination.PodName, termination.ContainerName), header, termination.LogLines)
+		}
+		return nil
+	})
+	feed.OnPodUnschedulable(func(failure pod.PodSchedulingFailure) error {
+		event := resource.WithType(display.UnschedulableEvent).WithPod(failure.PodName, "").WithMessage(failure.Message)
+		printer.Message(event, fmt.Sprintf("# job/%s po/%s unschedulable: %s", name, failure.PodName, failure.Summary()))
+		return nil
+	})
+	feed.OnPodContainerRestart(func(restart pod.PodContainerRestart) error {
+		event := resource.WithType(display.ContainerRestartedEvent).WithPod(restart.PodName, restart.ContainerName).WithMessage(restart.Summary())
+		printer.Message(event, fmt.Sprintf("# job/%s po/%s %s restarted: %s", name, restart.PodName, restart.ContainerName, restart.Summary()))
+		return nil
+	})
+	feed.OnStatusReport(func(status job.JobStatus) error {
+		printer.ResourceStatus(resource.WithType(display.StatusReportEvent).WithStatus(status))
 		return nil
 	})
 
@@ -0,0 +1,106 @@
+package follow
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/flant/kubedog/pkg/tracker"
+)
+
+// ResourceFilter matches resources by short kind name (po, deploy, sts, ds, job or * for any kind)
+// and name glob pattern (empty pattern matches any name)
+type ResourceFilter struct {
+	Kind        string
+	NamePattern string
+}
+
+// ParseResourceFilter parses filter in the form kind, kind/pattern or */pattern, e.g. deploy/api-*
+func ParseResourceFilter(filter string) (ResourceFilter, error) {
+	parts := strings.SplitN(filter, "/", 2)
+
+	res := ResourceFilter{Kind: parts[0]}
+	if len(parts) == 2 {
+		res.NamePattern = parts[1]
+	}
+
+	switch res.Kind {
+	case "po", "deploy", "sts", "ds", "job", "*":
+	default:
+		return ResourceFilter{}, fmt.Errorf("bad filter %q: unsupported kind %q, expected one of: po, deploy, sts, ds, job, *", filter, res.Kind)
+	}
+
+	if _, err := path.Match(res.NamePattern, ""); err != nil {
+		return ResourceFilter{}, fmt.Errorf("bad filter %q: %s", filter, err)
+	}
+
+	return res, nil
+}
+
+func (filter ResourceFilter) Match(kind, name string) bool {
+	if filter.Kind != "*" && filter.Kind != kind {
+		return false
+	}
+	if filter.NamePattern == "" {
+		return true
+	}
+	matched, _ := path.Match(filter.NamePattern, name)
+	return matched
+}
+
+type NamespaceOptions struct {
+	tracker.Options
+
+	// LabelSelector limits followed resources to resources matching the selector
+	LabelSelector string
+	// Include limits followed resources to resources matching any of filters, all resources are followed when empty
+	Include []ResourceFilter
+	// Exclude skips resources matching any of filters
+	Exclude []ResourceFilter
+}
+
+// TrackNamespace follows all Deployments, StatefulSets, DaemonSets, Jobs and Pods not managed by them
+// in the namespace, including resources created later
+func TrackNamespace(namespace string, kube kubernetes.Interface, opts NamespaceOptions) error {
+	return trackResources([]string{"deploy", "sts", "ds", "job", "po"}, opts.LabelSelector, namespace, kube, opts.Options, func(kind string, obj metav1.Object) bool {
+		if kind == "po" && isManagedPod(obj) {
+			return false
+		}
+		return isResourceIncluded(kind, obj.GetName(), opts.Include, opts.Exclude)
+	})
+}
+
+// isManagedPod returns true when pod is followed through its controller
+func isManagedPod(obj metav1.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Controller == nil || !*ref.Controller {
+			continue
+		}
+		switch ref.Kind {
+		case "ReplicaSet", "StatefulSet", "DaemonSet", "Job":
+			return true
+		}
+	}
+	return false
+}
+
+func isResourceIncluded(kind, name string, include, exclude []ResourceFilter) bool {
+	for _, filter := range exclude {
+		if filter.Match(kind, name) {
+			return false
+		}
+	}
+
+	if len(include) == 0 {
+		return true
+	}
+	for _, filter := range include {
+		if filter.Match(kind, name) {
+			return true
+		}
+	}
+	return false
+}
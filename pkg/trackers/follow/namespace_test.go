@@ -0,0 +1,112 @@
+package follow
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseResourceFilter(t *testing.T) {
+	tests := []struct {
+		filter   string
+		expected ResourceFilter
+		err      string
+	}{
+		{filter: "deploy", expected: ResourceFilter{Kind: "deploy"}},
+		{filter: "po/", expected: ResourceFilter{Kind: "po"}},
+		{filter: "deploy/api-*", expected: ResourceFilter{Kind: "deploy", NamePattern: "api-*"}},
+		{filter: "*/migrate-[0-9]*", expected: ResourceFilter{Kind: "*", NamePattern: "migrate-[0-9]*"}},
+		{filter: "job/db/migrate", expected: ResourceFilter{Kind: "job", NamePattern: "db/migrate"}},
+		{filter: "", err: "unsupported kind \"\""},
+		{filter: "deployment/api", err: "unsupported kind \"deployment\""},
+		{filter: "api-*", err: "unsupported kind \"api-*\""},
+		{filter: "sts/db-[", err: "bad filter \"sts/db-[\": syntax error in pattern"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			filter, err := ParseResourceFilter(test.filter)
+
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if filter != test.expected {
+				t.Errorf("expected %#v, got %#v", test.expected, filter)
+			}
+		})
+	}
+}
+
+func TestIsResourceIncluded(t *testing.T) {
+	parseFilters := func(filters ...string) []ResourceFilter {
+		res := []ResourceFilter{}
+		for _, filter := range filters {
+			parsed, err := ParseResourceFilter(filter)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			res = append(res, parsed)
+		}
+		return res
+	}
+
+	tests := []struct {
+		name     string
+		kind     string
+		resource string
+		include  []ResourceFilter
+		exclude  []ResourceFilter
+		expected bool
+	}{
+		{name: "no filters", kind: "deploy", resource: "api", expected: true},
+		{name: "included kind", kind: "deploy", resource: "api", include: parseFilters("sts", "deploy"), expected: true},
+		{name: "not included kind", kind: "job", resource: "api", include: parseFilters("sts", "deploy")},
+		{name: "included pattern", kind: "deploy", resource: "api-v2", include: parseFilters("deploy/api-*"), expected: true},
+		{name: "not included pattern", kind: "deploy", resource: "worker", include: parseFilters("deploy/api-*")},
+		{name: "any kind", kind: "job", resource: "api-migrate", include: parseFilters("*/api-*"), expected: true},
+		{name: "excluded", kind: "po", resource: "debug", exclude: parseFilters("po/debug"), expected: false},
+		{name: "not excluded", kind: "po", resource: "api", exclude: parseFilters("po/debug"), expected: true},
+		{name: "exclude wins", kind: "deploy", resource: "api-canary", include: parseFilters("deploy"), exclude: parseFilters("*/*-canary")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if included := isResourceIncluded(test.kind, test.resource, test.include, test.exclude); included != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, included)
+			}
+		})
+	}
+}
+
+func TestIsManagedPod(t *testing.T) {
+	isController := true
+	notController := false
+
+	tests := []struct {
+		name     string
+		refs     []metav1.OwnerReference
+		expected bool
+	}{
+		{name: "no owners"},
+		{name: "replicaset", refs: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "api-5d8f", Controller: &isController}}, expected: true},
+		{name: "job", refs: []metav1.OwnerReference{{Kind: "Job", Name: "migrate", Controller: &isController}}, expected: true},
+		{name: "not controller", refs: []metav1.OwnerReference{{Kind: "StatefulSet", Name: "db", Controller: &notController}}},
+		{name: "unknown controller", refs: []metav1.OwnerReference{{Kind: "Node", Name: "node-1", Controller: &isController}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{Name: "mypod", OwnerReferences: test.refs}
+			if managed := isManagedPod(obj); managed != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, managed)
+			}
+		})
+	}
+}
@@ -13,34 +13,60 @@ import (
 
 func TrackPod(name, namespace string, kube kubernetes.Interface, opts tracker.Options) error {
 	feed := pod.NewFeed()
+	resource := display.Event{Kind: "po", Namespace: namespace, Name: name}
+	printer := opts.GetPrinter()
 
 	feed.OnAdded(func() error {
-		fmt.Fprintf(display.Out, "# po/%s added\n", name)
+		printer.Message(resource.WithType(display.AddedEvent), fmt.Sprintf("# po/%s added", name))
 		return nil
 	})
 	feed.OnSucceeded(func() error {
-		fmt.Fprintf(display.Out, "# po/%s succeeded\n", name)
+		printer.Message(resource.WithType(display.SucceededEvent), fmt.Sprintf("# po/%s succeeded", name))
 		return nil
 	})
 	feed.OnFailed(func(reason string) error {
-		fmt.Fprintf(display.Out, "# po/%s failed: %s\n", name, reason)
+		printer.Message(resource.WithType(display.FailedEvent).WithMessage(reason), fmt.Sprintf("# po/%s failed: %s", name, reason))
 		return nil
 	})
 	feed.OnReady(func() error {
-		fmt.Fprintf(display.Out, "# po/%s become READY\n", name)
+		printer.Message(resource.WithType(display.ReadyEvent), fmt.Sprintf("# po/%s become READY", name))
 		return nil
 	})
 	feed.OnEventMsg(func(msg string) error {
-		fmt.Fprintf(display.Out, "# po/%s event: %s\n", name, msg)
+		printer.Message(resource.WithType(display.EventMsgEvent).WithMessage(msg), fmt.Sprintf("# po/%s event: %s", name, msg))
 		return nil
 	})
 	feed.OnContainerError(func(containerError pod.ContainerError) error {
-		fmt.Fprintf(display.Out, "# po/%s %s error: %s\n", name, containerError.ContainerName, containerError.Message)
+		event := resource.WithType(display.PodErrorEvent).WithPod(name, containerError.ContainerName).WithMessage(containerError.Message)
+		printer.Message(event, fmt.Sprintf("# po/%s %s error: %s", name, containerError.ContainerName, containerError.Message))
 		return nil
 	})
 	feed.OnContainerLogChunk(func(chunk *pod.ContainerLogChunk) error {
 		header := fmt.Sprintf("po/%s %s", name, chunk.ContainerName)
-		display.OutputLogLines(header, chunk.LogLines)
+		printer.LogLines(resource.WithPod(name, chunk.ContainerName), header, chunk.LogLines)
+		return nil
+	})
+	feed.OnContainerTermination(func(termination pod.ContainerTermination) error {
+		event := resource.WithType(display.ContainerTerminatedEvent).WithPod(name, termination.ContainerName).WithMessage(termination.Summary())
+		printer.Message(event, fmt.Sprintf("# po/%s %s terminated: %s", name, termination.ContainerName, termination.Summary()))
+		if len(termination.LogLines) > 0 {
+			header := fmt.Sprintf("po/%s %s (terminated)", name, termination.ContainerName)
+			printer.LogLines(resource.WithPod(name, termination.ContainerName), header, termination.LogLines)
+		}
+		return nil
+	})
+	feed.OnUnschedulable(func(failure pod.SchedulingFailure) error {
+		event := resource.WithType(display.UnschedulableEvent).WithPod(name, "").WithMessage(failure.Message)
+		printer.Message(event, fmt.Sprintf("# po/%s unschedulable: %s", name, failure.Summary()))
+		return nil
+	})
+	feed.OnContainerRestart(func(restart pod.ContainerRestart) error {
+		event := resource.WithType(display.ContainerRestartedEvent).WithPod(name, restart.ContainerName).WithMessage(restart.Summary())
+		printer.Message(event, fmt.Sprintf("# po/%s %s restarted: %s", name, restart.ContainerName, restart.Summary()))
+		return nil
+	})
+	feed.OnStatusReport(func(status pod.PodStatus) error {
+		printer.ResourceStatus(resource.WithType(display.StatusReportEvent).WithStatus(status))
 		return nil
 	})
 
@@ -0,0 +1,204 @@
+package follow
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	"github.com/flant/kubedog/pkg/tracker"
+)
+
+// TrackBySelector follows all resources of the kind matching the label selector in the namespace.
+// Resources which appear later and match the selector are followed too.
+// Kind is a short kind name: po, deploy, sts, ds or job.
+func TrackBySelector(kind, labelSelector, namespace string, kube kubernetes.Interface, opts tracker.Options) error {
+	return trackResources([]string{kind}, labelSelector, namespace, kube, opts, func(kind string, obj metav1.Object) bool {
+		return true
+	})
+}
+
+// trackResources follows all resources of the kinds matching the label selector and accepted by accept func,
+// including resources which appear later
+func trackResources(kinds []string, labelSelector, namespace string, kube kubernetes.Interface, opts tracker.Options, accept func(kind string, obj metav1.Object) bool) error {
+	parentContext := opts.ParentContext
+	if parentContext == nil {
+		parentContext = context.Background()
+	}
+	ctx, cancel := watchtools.ContextWithOptionalTimeout(parentContext, opts.Timeout)
+	defer cancel()
+
+	trackerOpts := opts
+	trackerOpts.ParentContext = ctx
+	trackerOpts.Timeout = 0
+
+	errorChan := make(chan error, 1)
+	sendError := func(err error) {
+		select {
+		case errorChan <- err:
+		default:
+		}
+		cancel()
+	}
+
+	var trackersWg sync.WaitGroup
+	var watchersWg sync.WaitGroup
+
+	for _, kind := range kinds {
+		trackFunc, objType, lw, err := selectorListWatch(kind, labelSelector, namespace, kube)
+		if err != nil {
+			return err
+		}
+
+		// trackedUIDs maps names of followed resources to UIDs of the last added resources with the names.
+		// Tracker follows the resource by name, so the resource recreated while its tracker is running
+		// is followed by the same tracker, and the tracker is started again when it has exited before.
+		trackedUIDs := make(map[string]types.UID)
+		var trackedMux sync.Mutex
+
+		var startTracker func(kind, name string, uid types.UID)
+		startTracker = func(kind, name string, uid types.UID) {
+			trackersWg.Add(1)
+			go func() {
+				defer trackersWg.Done()
+
+				err := trackFunc(name, namespace, kube, trackerOpts)
+				if err != nil && err != tracker.ErrTrackInterrupted {
+					sendError(fmt.Errorf("%s/%s: %s", kind, name, err))
+				}
+
+				trackedMux.Lock()
+				defer trackedMux.Unlock()
+
+				lastUID := trackedUIDs[name]
+				delete(trackedUIDs, name)
+
+				if lastUID != uid && ctx.Err() == nil {
+					trackedUIDs[name] = lastUID
+					startTracker(kind, name, lastUID)
+				}
+			}()
+		}
+
+		watchersWg.Add(1)
+		go func(kind string) {
+			defer watchersWg.Done()
+
+			_, err := watchtools.UntilWithSync(ctx, lw, objType, nil, func(e watch.Event) (bool, error) {
+				switch e.Type {
+				case watch.Added:
+				case watch.Error:
+					return true, fmt.Errorf("%s list watch error: %v", kind, e.Object)
+				default:
+					return false, nil
+				}
+
+				accessor, err := meta.Accessor(e.Object)
+				if err != nil {
+					return true, err
+				}
+
+				if !accept(kind, accessor) {
+					return false, nil
+				}
+
+				trackedMux.Lock()
+				defer trackedMux.Unlock()
+
+				name := accessor.GetName()
+				_, isTracked := trackedUIDs[name]
+				trackedUIDs[name] = accessor.GetUID()
+				if !isTracked {
+					startTracker(kind, name, accessor.GetUID())
+				}
+
+				return false, nil
+			})
+
+			if err != nil && ctx.Err() == nil {
+				sendError(err)
+			}
+		}(kind)
+	}
+
+	watchersWg.Wait()
+	cancel()
+	trackersWg.Wait()
+
+	select {
+	case err := <-errorChan:
+		return err
+	default:
+	}
+
+	return tracker.ErrTrackInterrupted
+}
+
+func selectorListWatch(kind, labelSelector, namespace string, kube kubernetes.Interface) (func(name, namespace string, kube kubernetes.Interface, opts tracker.Options) error, runtime.Object, *cache.ListWatch, error) {
+	tweakListOptions := func(options metav1.ListOptions) metav1.ListOptions {
+		options.LabelSelector = labelSelector
+		return options
+	}
+
+	switch kind {
+	case "po":
+		return TrackPod, &corev1.Pod{}, &cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return kube.CoreV1().Pods(namespace).List(tweakListOptions(options))
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return kube.CoreV1().Pods(namespace).Watch(tweakListOptions(options))
+			},
+		}, nil
+	case "deploy":
+		return TrackDeployment, &extensions.Deployment{}, &cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return kube.ExtensionsV1beta1().Deployments(namespace).List(tweakListOptions(options))
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return kube.ExtensionsV1beta1().Deployments(namespace).Watch(tweakListOptions(options))
+			},
+		}, nil
+	case "sts":
+		return TrackStatefulSet, &appsv1.StatefulSet{}, &cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return kube.AppsV1().StatefulSets(namespace).List(tweakListOptions(options))
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return kube.AppsV1().StatefulSets(namespace).Watch(tweakListOptions(options))
+			},
+		}, nil
+	case "ds":
+		return TrackDaemonSet, &extensions.DaemonSet{}, &cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return kube.ExtensionsV1beta1().DaemonSets(namespace).List(tweakListOptions(options))
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return kube.ExtensionsV1beta1().DaemonSets(namespace).Watch(tweakListOptions(options))
+			},
+		}, nil
+	case "job":
+		return TrackJob, &batchv1.Job{}, &cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return kube.BatchV1().Jobs(namespace).List(tweakListOptions(options))
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return kube.BatchV1().Jobs(namespace).Watch(tweakListOptions(options))
+			},
+		}, nil
+	default:
+		return nil, nil, nil, fmt.Errorf("unsupported kind %q, expected one of: po, deploy, sts, ds, job", kind)
+	}
+}
@@ -0,0 +1,93 @@
+package follow
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/flant/kubedog/pkg/display"
+	"github.com/flant/kubedog/pkg/tracker"
+)
+
+func TestTrackBySelectorFollowsRecreatedResource(t *testing.T) {
+	newJob := func(uid types.UID) *batchv1.Job {
+		labels := map[string]string{"app": "migrate"}
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "myns", UID: uid, Labels: labels},
+			Spec:       batchv1.JobSpec{Selector: &metav1.LabelSelector{MatchLabels: labels}},
+		}
+	}
+
+	kube := fake.NewSimpleClientset(newJob("uid-1"))
+	out := &syncBuffer{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	trackErr := make(chan error, 1)
+	go func() {
+		trackErr <- TrackBySelector("job", "app=migrate", "myns", kube, tracker.Options{ParentContext: ctx, Printer: display.NewTextPrinter(out)})
+	}()
+
+	out.waitCount(t, "# job/migrate added", 1)
+
+	// Job tracker exits when the job is deleted
+	if err := kube.BatchV1().Jobs("myns").Delete("migrate", &metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := kube.BatchV1().Jobs("myns").Create(newJob("uid-2")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	out.waitCount(t, "# job/migrate added", 2)
+
+	cancel()
+
+	select {
+	case err := <-trackErr:
+		if err != tracker.ErrTrackInterrupted {
+			t.Fatalf("expected %q error, got %v", tracker.ErrTrackInterrupted, err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("TrackBySelector has not returned after the context cancel")
+	}
+}
+
+// syncBuffer is the printer output safe for concurrent use
+type syncBuffer struct {
+	mux sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	return b.buf.String()
+}
+
+// waitCount waits for the output to contain the expected count of lines with the text
+func (b *syncBuffer) waitCount(t *testing.T, text string, expected int) {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for strings.Count(b.String(), text) < expected {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d lines with %q in output:\n%s", expected, text, b.String())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -14,54 +14,93 @@ import (
 // TrackDeploymentTillReady
 func TrackDeploymentTillReady(name, namespace string, kube kubernetes.Interface, opts tracker.Options) error {
 	feed := deployment.NewFeed()
+	resource := display.Event{Kind: "deploy", Namespace: namespace, Name: name}
+	printer := opts.GetPrinter()
+	var lastTermination *containerTermination
 
 	feed.OnAdded(func(ready bool) error {
 		if ready {
-			fmt.Fprintf(display.Out, "# deploy/%s appears to be ready\n", name)
+			printer.Message(resource.WithType(display.ReadyEvent), fmt.Sprintf("# deploy/%s appears to be ready", name))
 			return tracker.StopTrack
 		}
-		fmt.Fprintf(display.Out, "# deploy/%s added\n", name)
+		printer.Message(resource.WithType(display.AddedEvent), fmt.Sprintf("# deploy/%s added", name))
 		return nil
 	})
 	feed.OnReady(func() error {
-		fmt.Fprintf(display.Out, "# deploy/%s become READY\n", name)
+		printer.Message(resource.WithType(display.ReadyEvent), fmt.Sprintf("# deploy/%s become READY", name))
 		return tracker.StopTrack
 	})
 	feed.OnFailed(func(reason string) error {
-		fmt.Fprintf(display.Out, "# deploy/%s FAIL: %s\n", name, reason)
+		reason = withLastContainerTermination(reason, lastTermination)
+		printer.Message(resource.WithType(display.FailedEvent).WithMessage(reason), fmt.Sprintf("# deploy/%s FAIL: %s", name, reason))
 		return tracker.ResourceErrorf("failed: %s", reason)
 	})
 	feed.OnEventMsg(func(msg string) error {
-		fmt.Fprintf(display.Out, "# deploy/%s event: %s\n", name, msg)
+		printer.Message(resource.WithType(display.EventMsgEvent).WithMessage(msg), fmt.Sprintf("# deploy/%s event: %s", name, msg))
 		return nil
 	})
 	feed.OnAddedReplicaSet(func(rs replicaset.ReplicaSet) error {
 		if !rs.IsNew {
 			return nil
 		}
-		fmt.Fprintf(display.Out, "# deploy/%s rs/%s added\n", name, rs.Name)
+		printer.Message(resource.WithType(display.AddedReplicaSetEvent).WithReplicaSet(rs.Name), fmt.Sprintf("# deploy/%s rs/%s added", name, rs.Name))
 		return nil
 	})
 	feed.OnAddedPod(func(pod replicaset.ReplicaSetPod) error {
 		if !pod.ReplicaSet.IsNew {
 			return nil
 		}
-		fmt.Fprintf(display.Out, "# deploy/%s po/%s added\n", name, pod.Name)
+		printer.Message(resource.WithType(display.AddedPodEvent).WithReplicaSet(pod.ReplicaSet.Name).WithPod(pod.Name, ""), fmt.Sprintf("# deploy/%s po/%s added", name, pod.Name))
 		return nil
 	})
 	feed.OnPodError(func(podError replicaset.ReplicaSetPodError) error {
 		if !podError.ReplicaSet.IsNew {
 			return nil
 		}
-		fmt.Fprintf(display.Out, "# deploy/%s po/%s %s error: %s\n", name, podError.PodName, podError.ContainerName, podError.Message)
-		return tracker.ResourceErrorf("deploy/%s po/%s %s failed: %s", name, podError.PodName, podError.ContainerName, podError.Message)
+		event := resource.WithType(display.PodErrorEvent).WithReplicaSet(podError.ReplicaSet.Name).WithPod(podError.PodName, podError.ContainerName).WithMessage(podError.Message)
+		printer.Message(event, fmt.Sprintf("# deploy/%s po/%s %s error: %s", name, podError.PodName, podError.ContainerName, podError.Message))
+		message := withLastContainerTermination(podError.Message, lastTermination)
+		return tracker.ResourceErrorf("deploy/%s po/%s %s failed: %s", name, podError.PodName, podError.ContainerName, message)
 	})
 	feed.OnPodLogChunk(func(chunk *replicaset.ReplicaSetPodLogChunk) error {
 		if !chunk.ReplicaSet.IsNew {
 			return nil
 		}
 		header := fmt.Sprintf("po/%s %s", chunk.PodName, chunk.ContainerName)
-		display.OutputLogLines(header, chunk.LogLines)
+		printer.LogLines(resource.WithReplicaSet(chunk.ReplicaSet.Name).WithPod(chunk.PodName, chunk.ContainerName), header, chunk.LogLines)
+		return nil
+	})
+	feed.OnPodContainerTermination(func(termination replicaset.ReplicaSetPodContainerTermination) error {
+		if !termination.ReplicaSet.IsNew {
+			return nil
+		}
+		lastTermination = &containerTermination{PodName: termination.PodName, ContainerName: termination.ContainerName, Summary: termination.Summary()}
+		event := resource.WithType(display.ContainerTerminatedEvent).WithReplicaSet(termination.ReplicaSet.Name).WithPod(termination.PodName, termination.ContainerName).WithMessage(termination.Summary())
+		printer.Message(event, fmt.Sprintf("# deploy/%s po/%s %s terminated: %s", name, termination.PodName, termination.ContainerName, termination.Summary()))
+		if len(termination.LogLines) > 0 {
+			header := fmt.Sprintf("po/%s %s (terminated)", termination.PodName, termination.ContainerName)
+			printer.LogLines(resource.WithReplicaSet(termination.ReplicaSet.Name).WithPod(termination.PodName, termination.ContainerName), header, termination.LogLines)
+		}
+		return nil
+	})
+	feed.OnPodUnschedulable(func(failure replicaset.ReplicaSetPodSchedulingFailure) error {
+		if !failure.ReplicaSet.IsNew {
+			return nil
+		}
+		event := resource.WithType(display.UnschedulableEvent).WithReplicaSet(failure.ReplicaSet.Name).WithPod(failure.PodName, "").WithMessage(failure.Message)
+		printer.Message(event, fmt.Sprintf("# deploy/%s po/%s unschedulable: %s", name, failure.PodName, failure.Summary()))
+		return nil
+	})
+	feed.OnPodContainerRestart(func(restart replicaset.ReplicaSetPodContainerRestart) error {
+		if !restart.ReplicaSet.IsNew {
+			return nil
+		}
+		event := resource.WithType(display.ContainerRestartedEvent).WithReplicaSet(restart.ReplicaSet.Name).WithPod(restart.PodName, restart.ContainerName).WithMessage(restart.Summary())
+		printer.Message(event, fmt.Sprintf("# deploy/%s po/%s %s restarted: %s", name, restart.PodName, restart.ContainerName, restart.Summary()))
+		return nil
+	})
+	feed.OnStatusReport(func(status deployment.DeploymentStatus) error {
+		printer.ResourceStatus(resource.WithType(display.StatusReportEvent).WithStatus(status))
 		return nil
 	})
 
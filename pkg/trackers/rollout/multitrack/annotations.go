@@ -0,0 +1,249 @@
+package multitrack
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/flant/kubedog/pkg/display"
+)
+
+const (
+	FailModeAnnoName                    = "kubedog.io/fail-mode"
+	AllowFailuresCountAnnoName          = "kubedog.io/allow-failures-count"
+	FailureThresholdSecondsAnnoName     = "kubedog.io/failure-threshold-seconds"
+	TimeoutSecondsAnnoName              = "kubedog.io/timeout-seconds"
+	NoProgressTimeoutSecondsAnnoName    = "kubedog.io/no-progress-timeout-seconds"
+	UnschedulableTimeoutSecondsAnnoName = "kubedog.io/unschedulable-timeout-seconds"
+	MaxRestartsAnnoName                 = "kubedog.io/max-restarts"
+	LogWatchRegexAnnoName               = "kubedog.io/log-watch-regex"
+	LogMinLevelAnnoName                 = "kubedog.io/log-min-level"
+	FailOnLogRegexAnnoName              = "kubedog.io/fail-on-log-regex"
+	SucceedOnLogRegexAnnoName           = "kubedog.io/succeed-on-log-regex"
+	ShowLogsUntilAnnoName               = "kubedog.io/show-logs-until"
+	SkipLogsForContainersAnnoName       = "kubedog.io/skip-logs-for-containers"
+	ShowLogsOnlyForContainersAnnoName   = "kubedog.io/show-logs-only-for-containers"
+
+	// LogWatchRegexForAnnoPrefix is followed by container name: kubedog.io/log-watch-regex-for-mycontainer
+	LogWatchRegexForAnnoPrefix = "kubedog.io/log-watch-regex-for-"
+	// FailOnLogRegexForAnnoPrefix is followed by container name: kubedog.io/fail-on-log-regex-for-mycontainer
+	FailOnLogRegexForAnnoPrefix = "kubedog.io/fail-on-log-regex-for-"
+	// SucceedOnLogRegexForAnnoPrefix is followed by container name: kubedog.io/succeed-on-log-regex-for-mycontainer
+	SucceedOnLogRegexForAnnoPrefix = "kubedog.io/succeed-on-log-regex-for-"
+)
+
+// ApplySpecAnnotations sets spec fields with values from kubedog.io/* annotations of the tracked object.
+// Fields already set in the spec are kept, so values passed explicitly win over annotations.
+// Resource is a short resource name like deploy/mydeploy used in error messages.
+func ApplySpecAnnotations(spec *MultitrackSpec, resource string, annotations map[string]string) error {
+	annoError := func(name, value string, format string, a ...interface{}) error {
+		return fmt.Errorf("%s in ns/%s: invalid annotation %s=%q: %s", resource, spec.Namespace, name, value, fmt.Sprintf(format, a...))
+	}
+
+	var skipLogsForContainers, showLogsOnlyForContainers []string
+
+	names := make([]string, 0, len(annotations))
+	for name := range annotations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := annotations[name]
+
+		switch {
+		case name == FailModeAnnoName:
+			failMode := FailMode(value)
+			switch failMode {
+			case IgnoreAndContinueDeployProcess, FailWholeDeployProcessImmediately, HopeUntilEndOfDeployProcess:
+				if spec.FailMode == "" {
+					spec.FailMode = failMode
+				}
+			default:
+				return annoError(name, value, "expected one of: %s, %s, %s", IgnoreAndContinueDeployProcess, FailWholeDeployProcessImmediately, HopeUntilEndOfDeployProcess)
+			}
+
+		case name == AllowFailuresCountAnnoName:
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 0 {
+				return annoError(name, value, "expected non-negative integer")
+			}
+			if spec.AllowFailuresCount == nil {
+				spec.AllowFailuresCount = &count
+			}
+
+		case name == FailureThresholdSecondsAnnoName:
+			seconds, err := strconv.Atoi(value)
+			if err != nil || seconds < 0 {
+				return annoError(name, value, "expected non-negative integer")
+			}
+			if spec.FailureThresholdSeconds == nil {
+				spec.FailureThresholdSeconds = &seconds
+			}
+
+		case name == TimeoutSecondsAnnoName:
+			seconds, err := strconv.Atoi(value)
+			if err != nil || seconds < 0 {
+				return annoError(name, value, "expected non-negative integer")
+			}
+			if spec.TimeoutSeconds == 0 {
+				spec.TimeoutSeconds = seconds
+			}
+
+		case name == NoProgressTimeoutSecondsAnnoName:
+			seconds, err := strconv.Atoi(value)
+			if err != nil || seconds < 0 {
+				return annoError(name, value, "expected non-negative integer")
+			}
+			if spec.NoProgressTimeoutSeconds == 0 {
+				spec.NoProgressTimeoutSeconds = seconds
+			}
+
+		case name == UnschedulableTimeoutSecondsAnnoName:
+			seconds, err := strconv.Atoi(value)
+			if err != nil || seconds < 0 {
+				return annoError(name, value, "expected non-negative integer")
+			}
+			if spec.UnschedulableTimeoutSeconds == 0 {
+				spec.UnschedulableTimeoutSeconds = seconds
+			}
+
+		case name == MaxRestartsAnnoName:
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 0 {
+				return annoError(name, value, "expected non-negative integer")
+			}
+			if spec.MaxRestarts == nil {
+				spec.MaxRestarts = &count
+			}
+
+		case name == LogWatchRegexAnnoName, name == FailOnLogRegexAnnoName, name == SucceedOnLogRegexAnnoName:
+			regex, err := regexp.Compile(value)
+			if err != nil {
+				return annoError(name, value, "%s", err)
+			}
+
+			switch {
+			case name == LogWatchRegexAnnoName && spec.LogWatchRegex == nil:
+				spec.LogWatchRegex = regex
+			case name == FailOnLogRegexAnnoName && spec.FailOnLogRegex == nil:
+				spec.FailOnLogRegex = regex
+			case name == SucceedOnLogRegexAnnoName && spec.SucceedOnLogRegex == nil:
+				spec.SucceedOnLogRegex = regex
+			}
+
+		case name == LogMinLevelAnnoName:
+			logMinLevel, err := display.ParseLogLevel(value)
+			if err != nil {
+				return annoError(name, value, "expected one of: trace, debug, info, warn, error, fatal")
+			}
+			if spec.LogMinLevel == display.UnknownLogLevel {
+				spec.LogMinLevel = logMinLevel
+			}
+
+		case strings.HasPrefix(name, LogWatchRegexForAnnoPrefix):
+			containerName := strings.TrimPrefix(name, LogWatchRegexForAnnoPrefix)
+			if containerName == "" {
+				return annoError(name, value, "container name expected after %s", LogWatchRegexForAnnoPrefix)
+			}
+
+			logWatchRegex, err := regexp.Compile(value)
+			if err != nil {
+				return annoError(name, value, "%s", err)
+			}
+			if _, hasKey := spec.LogWatchRegexByContainerName[containerName]; !hasKey {
+				spec.LogWatchRegexByContainerName = withContainerRegexp(spec.LogWatchRegexByContainerName, containerName, logWatchRegex)
+			}
+
+		case strings.HasPrefix(name, FailOnLogRegexForAnnoPrefix):
+			containerName := strings.TrimPrefix(name, FailOnLogRegexForAnnoPrefix)
+			if containerName == "" {
+				return annoError(name, value, "container name expected after %s", FailOnLogRegexForAnnoPrefix)
+			}
+
+			failOnLogRegex, err := regexp.Compile(value)
+			if err != nil {
+				return annoError(name, value, "%s", err)
+			}
+			if _, hasKey := spec.FailOnLogRegexByContainerName[containerName]; !hasKey {
+				spec.FailOnLogRegexByContainerName = withContainerRegexp(spec.FailOnLogRegexByContainerName, containerName, failOnLogRegex)
+			}
+
+		case strings.HasPrefix(name, SucceedOnLogRegexForAnnoPrefix):
+			containerName := strings.TrimPrefix(name, SucceedOnLogRegexForAnnoPrefix)
+			if containerName == "" {
+				return annoError(name, value, "container name expected after %s", SucceedOnLogRegexForAnnoPrefix)
+			}
+
+			succeedOnLogRegex, err := regexp.Compile(value)
+			if err != nil {
+				return annoError(name, value, "%s", err)
+			}
+			if _, hasKey := spec.SucceedOnLogRegexByContainerName[containerName]; !hasKey {
+				spec.SucceedOnLogRegexByContainerName = withContainerRegexp(spec.SucceedOnLogRegexByContainerName, containerName, succeedOnLogRegex)
+			}
+
+		case name == ShowLogsUntilAnnoName:
+			condition := DeployCondition(value)
+			switch condition {
+			case ControllerIsReady, PodIsReady, EndOfDeploy:
+				if spec.ShowLogsUntil == "" {
+					spec.ShowLogsUntil = condition
+				}
+			default:
+				return annoError(name, value, "expected one of: %s, %s, %s", ControllerIsReady, PodIsReady, EndOfDeploy)
+			}
+
+		case name == SkipLogsForContainersAnnoName:
+			containers, err := parseContainersList(value)
+			if err != nil {
+				return annoError(name, value, "%s", err)
+			}
+			skipLogsForContainers = containers
+
+		case name == ShowLogsOnlyForContainersAnnoName:
+			containers, err := parseContainersList(value)
+			if err != nil {
+				return annoError(name, value, "%s", err)
+			}
+			showLogsOnlyForContainers = containers
+		}
+	}
+
+	if len(skipLogsForContainers) > 0 && len(showLogsOnlyForContainers) > 0 {
+		return fmt.Errorf("%s in ns/%s: annotations %s and %s cannot be used together", resource, spec.Namespace, SkipLogsForContainersAnnoName, ShowLogsOnlyForContainersAnnoName)
+	}
+	// containers lists exclude each other, so the list passed explicitly disables both annotations
+	if len(spec.SkipLogsForContainers) == 0 && len(spec.ShowLogsOnlyForContainers) == 0 {
+		spec.SkipLogsForContainers = skipLogsForContainers
+		spec.ShowLogsOnlyForContainers = showLogsOnlyForContainers
+	}
+
+	return nil
+}
+
+// withContainerRegexp returns a copy of regexps by container name with the regexp for the container,
+// the map of the spec is not modified as it may be shared with other specs
+func withContainerRegexp(regexpByContainerName map[string]*regexp.Regexp, containerName string, regex *regexp.Regexp) map[string]*regexp.Regexp {
+	newRegexpByContainerName := make(map[string]*regexp.Regexp)
+	for k, v := range regexpByContainerName {
+		newRegexpByContainerName[k] = v
+	}
+	newRegexpByContainerName[containerName] = regex
+	return newRegexpByContainerName
+}
+
+// parseContainersList parses comma separated list of container names
+func parseContainersList(value string) ([]string, error) {
+	containers := []string{}
+	for _, part := range strings.Split(value, ",") {
+		containerName := strings.TrimSpace(part)
+		if containerName == "" {
+			return nil, fmt.Errorf("expected comma separated list of container names")
+		}
+		containers = appendElemIfNotExist(containers, containerName)
+	}
+	return containers, nil
+}
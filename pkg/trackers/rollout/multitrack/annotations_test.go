@@ -0,0 +1,211 @@
+package multitrack
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/flant/kubedog/pkg/display"
+)
+
+func TestApplySpecAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        *MultitrackSpec
+		annotations map[string]string
+		err         string
+		verify      func(t *testing.T, spec MultitrackSpec)
+	}{
+		{
+			name: "failures budget and timeouts",
+			annotations: map[string]string{
+				FailModeAnnoName:                    "HopeUntilEndOfDeployProcess",
+				AllowFailuresCountAnnoName:          "3",
+				FailureThresholdSecondsAnnoName:     "60",
+				TimeoutSecondsAnnoName:              "600",
+				NoProgressTimeoutSecondsAnnoName:    "120",
+				UnschedulableTimeoutSecondsAnnoName: "300",
+				MaxRestartsAnnoName:                 "0",
+				"kubedog.io/unknown":                "ignored",
+				"app.kubernetes.io/name":            "ignored",
+			},
+			verify: func(t *testing.T, spec MultitrackSpec) {
+				if spec.FailMode != HopeUntilEndOfDeployProcess {
+					t.Errorf("expected FailMode %s, got %s", HopeUntilEndOfDeployProcess, spec.FailMode)
+				}
+				if spec.AllowFailuresCount == nil || *spec.AllowFailuresCount != 3 {
+					t.Errorf("expected AllowFailuresCount 3, got %v", spec.AllowFailuresCount)
+				}
+				if spec.FailureThresholdSeconds == nil || *spec.FailureThresholdSeconds != 60 {
+					t.Errorf("expected FailureThresholdSeconds 60, got %v", spec.FailureThresholdSeconds)
+				}
+				if spec.TimeoutSeconds != 600 || spec.NoProgressTimeoutSeconds != 120 || spec.UnschedulableTimeoutSeconds != 300 {
+					t.Errorf("expected timeouts 600, 120 and 300, got %d, %d and %d", spec.TimeoutSeconds, spec.NoProgressTimeoutSeconds, spec.UnschedulableTimeoutSeconds)
+				}
+				if spec.MaxRestarts == nil || *spec.MaxRestarts != 0 {
+					t.Errorf("expected MaxRestarts 0, got %v", spec.MaxRestarts)
+				}
+			},
+		},
+		{
+			name: "logs options",
+			annotations: map[string]string{
+				LogWatchRegexAnnoName:                 "ERROR",
+				LogWatchRegexForAnnoPrefix + "main":   "WARN",
+				FailOnLogRegexAnnoName:                "FATAL",
+				SucceedOnLogRegexForAnnoPrefix + "db": "ready to accept connections",
+				LogMinLevelAnnoName:                   "warn",
+				ShowLogsUntilAnnoName:                 "EndOfDeploy",
+				SkipLogsForContainersAnnoName:         "istio-proxy, init,istio-proxy",
+			},
+			verify: func(t *testing.T, spec MultitrackSpec) {
+				checkRegexp(t, "LogWatchRegex", spec.LogWatchRegex, "ERROR")
+				checkRegexp(t, "LogWatchRegexByContainerName[main]", spec.LogWatchRegexByContainerName["main"], "WARN")
+				checkRegexp(t, "FailOnLogRegex", spec.FailOnLogRegex, "FATAL")
+				checkRegexp(t, "SucceedOnLogRegexByContainerName[db]", spec.SucceedOnLogRegexByContainerName["db"], "ready to accept connections")
+
+				if spec.LogMinLevel != display.WarnLogLevel {
+					t.Errorf("expected LogMinLevel %s, got %s", display.WarnLogLevel, spec.LogMinLevel)
+				}
+				if spec.ShowLogsUntil != EndOfDeploy {
+					t.Errorf("expected ShowLogsUntil %s, got %s", EndOfDeploy, spec.ShowLogsUntil)
+				}
+				if expected := []string{"istio-proxy", "init"}; !reflect.DeepEqual(spec.SkipLogsForContainers, expected) {
+					t.Errorf("expected SkipLogsForContainers %v, got %v", expected, spec.SkipLogsForContainers)
+				}
+			},
+		},
+		{name: "bad fail mode", annotations: map[string]string{FailModeAnnoName: "Retry"}, err: "invalid annotation kubedog.io/fail-mode=\"Retry\""},
+		{name: "negative count", annotations: map[string]string{AllowFailuresCountAnnoName: "-1"}, err: "expected non-negative integer"},
+		{name: "not a number", annotations: map[string]string{TimeoutSecondsAnnoName: "10m"}, err: "expected non-negative integer"},
+		{name: "negative max restarts", annotations: map[string]string{MaxRestartsAnnoName: "-1"}, err: "expected non-negative integer"},
+		{name: "bad regex", annotations: map[string]string{FailOnLogRegexAnnoName: "("}, err: "invalid annotation kubedog.io/fail-on-log-regex"},
+		{name: "no container name", annotations: map[string]string{LogWatchRegexForAnnoPrefix: "ERROR"}, err: "container name expected"},
+		{name: "bad log level", annotations: map[string]string{LogMinLevelAnnoName: "loud"}, err: "expected one of"},
+		{name: "bad show logs until", annotations: map[string]string{ShowLogsUntilAnnoName: "Never"}, err: "expected one of"},
+		{name: "empty container name", annotations: map[string]string{ShowLogsOnlyForContainersAnnoName: "main,,db"}, err: "expected comma separated list"},
+		{
+			name: "skip and show only logs",
+			annotations: map[string]string{
+				SkipLogsForContainersAnnoName:     "main",
+				ShowLogsOnlyForContainersAnnoName: "db",
+			},
+			err: "cannot be used together",
+		},
+		{
+			name: "explicit values win",
+			spec: &MultitrackSpec{
+				ResourceName:                 "api",
+				Namespace:                    "default",
+				FailMode:                     IgnoreAndContinueDeployProcess,
+				AllowFailuresCount:           intPtr(0),
+				TimeoutSeconds:               30,
+				FailOnLogRegex:               regexp.MustCompile("panic:"),
+				LogWatchRegexByContainerName: map[string]*regexp.Regexp{"main": regexp.MustCompile("ERROR")},
+				SkipLogsForContainers:        []string{"istio-proxy"},
+			},
+			annotations: map[string]string{
+				FailModeAnnoName:                    "HopeUntilEndOfDeployProcess",
+				AllowFailuresCountAnnoName:          "3",
+				TimeoutSecondsAnnoName:              "600",
+				NoProgressTimeoutSecondsAnnoName:    "120",
+				FailOnLogRegexAnnoName:              "FATAL",
+				LogWatchRegexForAnnoPrefix + "main": "WARN",
+				LogWatchRegexForAnnoPrefix + "db":   "WARN",
+				ShowLogsOnlyForContainersAnnoName:   "main",
+			},
+			verify: func(t *testing.T, spec MultitrackSpec) {
+				if spec.FailMode != IgnoreAndContinueDeployProcess {
+					t.Errorf("expected FailMode %s, got %s", IgnoreAndContinueDeployProcess, spec.FailMode)
+				}
+				if spec.AllowFailuresCount == nil || *spec.AllowFailuresCount != 0 {
+					t.Errorf("expected AllowFailuresCount 0, got %v", spec.AllowFailuresCount)
+				}
+				if spec.TimeoutSeconds != 30 || spec.NoProgressTimeoutSeconds != 120 {
+					t.Errorf("expected timeouts 30 and 120, got %d and %d", spec.TimeoutSeconds, spec.NoProgressTimeoutSeconds)
+				}
+				checkRegexp(t, "FailOnLogRegex", spec.FailOnLogRegex, "panic:")
+				checkRegexp(t, "LogWatchRegexByContainerName[main]", spec.LogWatchRegexByContainerName["main"], "ERROR")
+				checkRegexp(t, "LogWatchRegexByContainerName[db]", spec.LogWatchRegexByContainerName["db"], "WARN")
+				if expected := []string{"istio-proxy"}; !reflect.DeepEqual(spec.SkipLogsForContainers, expected) || len(spec.ShowLogsOnlyForContainers) != 0 {
+					t.Errorf("expected SkipLogsForContainers %v only, got %v and ShowLogsOnlyForContainers %v", expected, spec.SkipLogsForContainers, spec.ShowLogsOnlyForContainers)
+				}
+			},
+		},
+		{
+			name:        "invalid annotation with explicit value",
+			spec:        &MultitrackSpec{ResourceName: "api", Namespace: "default", MaxRestarts: intPtr(1)},
+			annotations: map[string]string{MaxRestartsAnnoName: "many"},
+			err:         "expected non-negative integer",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec := MultitrackSpec{ResourceName: "api", Namespace: "default"}
+			if test.spec != nil {
+				spec = *test.spec
+			}
+			err := ApplySpecAnnotations(&spec, "deploy/api", test.annotations)
+			checkError(t, err, test.err)
+			if err == nil && test.verify != nil {
+				test.verify(t, spec)
+			}
+		})
+	}
+}
+
+func TestApplySpecAnnotationsDoesNotModifySharedRegexps(t *testing.T) {
+	shared := map[string]*regexp.Regexp{"main": regexp.MustCompile("ERROR")}
+	spec := MultitrackSpec{ResourceName: "api", Namespace: "default", LogWatchRegexByContainerName: shared}
+
+	if err := ApplySpecAnnotations(&spec, "deploy/api", map[string]string{LogWatchRegexForAnnoPrefix + "db": "WARN"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(shared) != 1 {
+		t.Errorf("shared regexps are modified: %v", shared)
+	}
+	if len(spec.LogWatchRegexByContainerName) != 2 {
+		t.Errorf("expected regexps for main and db containers, got %v", spec.LogWatchRegexByContainerName)
+	}
+}
+
+func checkRegexp(t *testing.T, name string, regex *regexp.Regexp, expected string) {
+	t.Helper()
+
+	if regex == nil || regex.String() != expected {
+		t.Errorf("expected %s %q, got %v", name, expected, regex)
+	}
+}
+
+func TestApplyObjectAnnotations(t *testing.T) {
+	id := ResourceID{Namespace: "default", Kind: "deploy", Name: "api"}
+	passedSpec := MultitrackSpec{ResourceName: "api", Namespace: "default", AllowFailuresCount: intPtr(2)}
+
+	spec := passedSpec
+	setDefaultSpecValues(&spec)
+
+	mt := &multitracker{passedSpecs: map[ResourceID]MultitrackSpec{id: passedSpec}}
+	err := mt.applyObjectAnnotations(id, &spec, map[string]string{
+		FailModeAnnoName:           "HopeUntilEndOfDeployProcess",
+		AllowFailuresCountAnnoName: "5",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if spec.FailMode != HopeUntilEndOfDeployProcess {
+		t.Errorf("expected FailMode from annotation %s, got %s", HopeUntilEndOfDeployProcess, spec.FailMode)
+	}
+	if spec.AllowFailuresCount == nil || *spec.AllowFailuresCount != 2 {
+		t.Errorf("expected passed AllowFailuresCount 2, got %v", spec.AllowFailuresCount)
+	}
+	if spec.ShowLogsUntil != PodIsReady {
+		t.Errorf("expected default ShowLogsUntil %s, got %s", PodIsReady, spec.ShowLogsUntil)
+	}
+}
+
+func intPtr(value int) *int {
+	return &value
+}
@@ -11,107 +11,136 @@ import (
 
 func (mt *multitracker) TrackDaemonSet(kube kubernetes.Interface, spec MultitrackSpec, opts MultitrackOptions) error {
 	feed := daemonset.NewFeed()
+	id := ResourceID{Namespace: spec.Namespace, Kind: "ds", Name: spec.ResourceName}
 
 	feed.OnAdded(func(ready bool) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.daemonsetAdded(spec, feed, ready)
+		if err := mt.applyObjectAnnotations(id, &spec, feed.GetStatus().Annotations); err != nil {
+			return err
+		}
+		return mt.daemonsetAdded(id, spec, feed, ready)
 	})
 	feed.OnReady(func() error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.daemonsetReady(spec, feed)
+		return mt.daemonsetReady(id, spec, feed)
 	})
 	feed.OnFailed(func(reason string) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.daemonsetFailed(spec, feed, reason)
+		return mt.daemonsetFailed(id, spec, feed, reason)
 	})
 	feed.OnEventMsg(func(msg string) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.daemonsetEventMsg(spec, feed, msg)
+		return mt.daemonsetEventMsg(id, spec, feed, msg)
 	})
 	feed.OnAddedReplicaSet(func(rs replicaset.ReplicaSet) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.daemonsetAddedReplicaSet(spec, feed, rs)
+		return mt.daemonsetAddedReplicaSet(id, spec, feed, rs)
 	})
 	feed.OnAddedPod(func(pod replicaset.ReplicaSetPod) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.daemonsetAddedPod(spec, feed, pod)
+		return mt.daemonsetAddedPod(id, spec, feed, pod)
 	})
 	feed.OnPodError(func(podError replicaset.ReplicaSetPodError) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.daemonsetPodError(spec, feed, podError)
+		return mt.daemonsetPodError(id, spec, feed, podError)
 	})
 	feed.OnPodLogChunk(func(chunk *replicaset.ReplicaSetPodLogChunk) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.daemonsetPodLogChunk(spec, feed, chunk)
+		return mt.daemonsetPodLogChunk(id, spec, feed, chunk)
+	})
+	feed.OnPodContainerTermination(func(termination replicaset.ReplicaSetPodContainerTermination) error {
+		mt.handlerMux.Lock()
+		defer mt.handlerMux.Unlock()
+		return mt.daemonsetPodContainerTermination(id, spec, feed, termination)
+	})
+	feed.OnPodUnschedulable(func(failure replicaset.ReplicaSetPodSchedulingFailure) error {
+		mt.handlerMux.Lock()
+		defer mt.handlerMux.Unlock()
+		return mt.daemonsetPodUnschedulable(id, spec, feed, failure)
+	})
+	feed.OnPodContainerRestart(func(restart replicaset.ReplicaSetPodContainerRestart) error {
+		mt.handlerMux.Lock()
+		defer mt.handlerMux.Unlock()
+		return mt.daemonsetPodContainerRestart(id, spec, feed, restart)
 	})
 	feed.OnStatusReport(func(status daemonset.DaemonSetStatus) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.daemonsetStatusReport(spec, feed, status)
+		return mt.daemonsetStatusReport(id, spec, feed, status)
 	})
 
 	return feed.Track(spec.ResourceName, spec.Namespace, kube, opts.Options)
 }
 
-func (mt *multitracker) daemonsetAdded(spec MultitrackSpec, feed daemonset.Feed, ready bool) error {
+func (mt *multitracker) daemonsetAdded(id ResourceID, spec MultitrackSpec, feed daemonset.Feed, ready bool) error {
 	if debug() {
 		fmt.Printf("-- daemonsetAdded %#v %#v\n", spec, ready)
 	}
 
+	mt.DaemonSetsSpecs[id] = spec
+
 	if ready {
-		mt.DaemonSetsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.DaemonSetsStatuses[id] = feed.GetStatus()
 
-		display.OutF("# ds/%s appears to be READY\n", spec.ResourceName)
+		mt.printer.Message(mt.resourceEvent(id, display.ReadyEvent), fmt.Sprintf("# %s appears to be READY", mt.resourceName(id)))
 
-		return mt.handleResourceReadyCondition(mt.TrackingDaemonSets, spec)
+		return mt.handleResourceReadyCondition(mt.TrackingDaemonSets, id, spec)
 	}
 
-	display.OutF("# ds/%s added\n", spec.ResourceName)
+	mt.printer.Message(mt.resourceEvent(id, display.AddedEvent), fmt.Sprintf("# %s added", mt.resourceName(id)))
 
 	return nil
 }
 
-func (mt *multitracker) daemonsetReady(spec MultitrackSpec, feed daemonset.Feed) error {
+func (mt *multitracker) daemonsetReady(id ResourceID, spec MultitrackSpec, feed daemonset.Feed) error {
 	if debug() {
 		fmt.Printf("-- daemonsetReady %#v\n", spec)
 	}
 
-	mt.DaemonSetsStatuses[spec.ResourceName] = feed.GetStatus()
+	if !mt.isResourceTracked(mt.TrackingDaemonSets, id) {
+		return nil
+	}
+
+	mt.DaemonSetsStatuses[id] = feed.GetStatus()
 
-	display.OutF("# ds/%s become READY\n", spec.ResourceName)
+	mt.printer.Message(mt.resourceEvent(id, display.ReadyEvent), fmt.Sprintf("# %s become READY", mt.resourceName(id)))
 
-	return mt.handleResourceReadyCondition(mt.TrackingDaemonSets, spec)
+	return mt.handleResourceReadyCondition(mt.TrackingDaemonSets, id, spec)
 }
 
-func (mt *multitracker) daemonsetFailed(spec MultitrackSpec, feed daemonset.Feed, reason string) error {
+func (mt *multitracker) daemonsetFailed(id ResourceID, spec MultitrackSpec, feed daemonset.Feed, reason string) error {
 	if debug() {
 		fmt.Printf("-- daemonsetFailed %#v %#v\n", spec, reason)
 	}
 
-	display.OutF("# ds/%s FAIL: %s\n", spec.ResourceName, reason)
+	if !mt.isResourceTracked(mt.TrackingDaemonSets, id) {
+		return nil
+	}
+
+	mt.printer.Message(mt.resourceEvent(id, display.FailedEvent).WithMessage(reason), fmt.Sprintf("# %s FAIL: %s", mt.resourceName(id), reason))
 
-	return mt.handleResourceFailure(mt.TrackingDaemonSets, spec, reason)
+	return mt.handleResourceFailure(mt.TrackingDaemonSets, id, spec, "", reason)
 }
 
-func (mt *multitracker) daemonsetEventMsg(spec MultitrackSpec, feed daemonset.Feed, msg string) error {
+func (mt *multitracker) daemonsetEventMsg(id ResourceID, spec MultitrackSpec, feed daemonset.Feed, msg string) error {
 	if debug() {
 		fmt.Printf("-- daemonsetEventMsg %#v %#v\n", spec, msg)
 	}
 
-	display.OutF("# ds/%s event: %s\n", spec.ResourceName, msg)
+	mt.printer.Message(mt.resourceEvent(id, display.EventMsgEvent).WithMessage(msg), fmt.Sprintf("# %s event: %s", mt.resourceName(id), msg))
 
 	return nil
 }
 
-func (mt *multitracker) daemonsetAddedReplicaSet(spec MultitrackSpec, feed daemonset.Feed, rs replicaset.ReplicaSet) error {
+func (mt *multitracker) daemonsetAddedReplicaSet(id ResourceID, spec MultitrackSpec, feed daemonset.Feed, rs replicaset.ReplicaSet) error {
 	if debug() {
 		fmt.Printf("-- daemonsetAddedReplicaSet %#v %#v\n", spec, rs)
 	}
@@ -119,12 +148,12 @@ func (mt *multitracker) daemonsetAddedReplicaSet(spec MultitrackSpec, feed daemo
 	if !rs.IsNew {
 		return nil
 	}
-	display.OutF("# ds/%s rs/%s added\n", spec.ResourceName, rs.Name)
+	mt.printer.Message(mt.resourceEvent(id, display.AddedReplicaSetEvent).WithReplicaSet(rs.Name), fmt.Sprintf("# %s rs/%s added", mt.resourceName(id), rs.Name))
 
 	return nil
 }
 
-func (mt *multitracker) daemonsetAddedPod(spec MultitrackSpec, feed daemonset.Feed, pod replicaset.ReplicaSetPod) error {
+func (mt *multitracker) daemonsetAddedPod(id ResourceID, spec MultitrackSpec, feed daemonset.Feed, pod replicaset.ReplicaSetPod) error {
 	if debug() {
 		fmt.Printf("-- daemonsetAddedPod %#v %#v\n", spec, pod)
 	}
@@ -132,28 +161,33 @@ func (mt *multitracker) daemonsetAddedPod(spec MultitrackSpec, feed daemonset.Fe
 	if !pod.ReplicaSet.IsNew {
 		return nil
 	}
-	display.OutF("# ds/%s po/%s added\n", spec.ResourceName, pod.Name)
+	mt.printer.Message(mt.resourceEvent(id, display.AddedPodEvent).WithPod(pod.Name, ""), fmt.Sprintf("# %s po/%s added", mt.resourceName(id), pod.Name))
 
 	return nil
 }
 
-func (mt *multitracker) daemonsetPodError(spec MultitrackSpec, feed daemonset.Feed, podError replicaset.ReplicaSetPodError) error {
+func (mt *multitracker) daemonsetPodError(id ResourceID, spec MultitrackSpec, feed daemonset.Feed, podError replicaset.ReplicaSetPodError) error {
 	if debug() {
 		fmt.Printf("-- daemonsetPodError %#v %#v\n", spec, podError)
 	}
 
+	if !mt.isResourceTracked(mt.TrackingDaemonSets, id) {
+		return nil
+	}
+
 	if !podError.ReplicaSet.IsNew {
 		return nil
 	}
 
 	reason := fmt.Sprintf("po/%s %s error: %s", podError.PodName, podError.ContainerName, podError.Message)
 
-	display.OutF("# ds/%s %s\n", spec.ResourceName, reason)
+	event := mt.resourceEvent(id, display.PodErrorEvent).WithPod(podError.PodName, podError.ContainerName).WithMessage(podError.Message)
+	mt.printer.Message(event, fmt.Sprintf("# %s %s", mt.resourceName(id), reason))
 
-	return mt.handleResourceFailure(mt.TrackingDaemonSets, spec, reason)
+	return mt.handleResourceFailure(mt.TrackingDaemonSets, id, spec, podError.PodName, reason)
 }
 
-func (mt *multitracker) daemonsetPodLogChunk(spec MultitrackSpec, feed daemonset.Feed, chunk *replicaset.ReplicaSetPodLogChunk) error {
+func (mt *multitracker) daemonsetPodLogChunk(id ResourceID, spec MultitrackSpec, feed daemonset.Feed, chunk *replicaset.ReplicaSetPodLogChunk) error {
 	if debug() {
 		fmt.Printf("-- daemonsetPodLogChunk %#v %#v\n", spec, chunk)
 	}
@@ -162,18 +196,59 @@ func (mt *multitracker) daemonsetPodLogChunk(spec MultitrackSpec, feed daemonset
 		return nil
 	}
 
-	header := fmt.Sprintf("ds/%s %s", spec.ResourceName, podContainerLogChunkHeader(chunk.PodName, chunk.ContainerLogChunk))
-	displayContainerLogChunk(header, spec, chunk.ContainerLogChunk)
+	header := fmt.Sprintf("%s %s", mt.resourceName(id), podContainerLogChunkHeader(chunk.PodName, chunk.ContainerLogChunk))
+	return mt.handleContainerLogChunk(mt.TrackingDaemonSets, id, spec, header, chunk.PodName, chunk.ContainerLogChunk, mt.DaemonSetsStatuses[id].Pods[chunk.PodName])
+}
+
+func (mt *multitracker) daemonsetPodContainerTermination(id ResourceID, spec MultitrackSpec, feed daemonset.Feed, termination replicaset.ReplicaSetPodContainerTermination) error {
+	if debug() {
+		fmt.Printf("-- daemonsetPodContainerTermination %#v %#v\n", spec, termination)
+	}
+
+	if !mt.isResourceTracked(mt.TrackingDaemonSets, id) {
+		return nil
+	}
+
+	event := mt.resourceEvent(id, display.ContainerTerminatedEvent).WithPod(termination.PodName, termination.ContainerName)
+	header := fmt.Sprintf("%s po/%s %s (terminated)", mt.resourceName(id), termination.PodName, termination.ContainerName)
+	return mt.handleContainerTermination(mt.TrackingDaemonSets, id, event, header, termination.PodContainerTermination)
+}
+
+func (mt *multitracker) daemonsetPodUnschedulable(id ResourceID, spec MultitrackSpec, feed daemonset.Feed, failure replicaset.ReplicaSetPodSchedulingFailure) error {
+	if debug() {
+		fmt.Printf("-- daemonsetPodUnschedulable %#v %#v\n", spec, failure)
+	}
+
+	if !mt.isResourceTracked(mt.TrackingDaemonSets, id) {
+		return nil
+	}
+
+	mt.handlePodUnschedulable(id, mt.resourceEvent(id, display.UnschedulableEvent), failure.PodName, failure.PodSchedulingFailure.SchedulingFailure)
 
 	return nil
 }
 
-func (mt *multitracker) daemonsetStatusReport(spec MultitrackSpec, feed daemonset.Feed, status daemonset.DaemonSetStatus) error {
+func (mt *multitracker) daemonsetPodContainerRestart(id ResourceID, spec MultitrackSpec, feed daemonset.Feed, restart replicaset.ReplicaSetPodContainerRestart) error {
+	if debug() {
+		fmt.Printf("-- daemonsetPodContainerRestart %#v %#v\n", spec, restart)
+	}
+
+	if !mt.isResourceTracked(mt.TrackingDaemonSets, id) {
+		return nil
+	}
+
+	event := mt.resourceEvent(id, display.ContainerRestartedEvent).WithPod(restart.PodName, restart.ContainerName)
+	return mt.handleContainerRestart(mt.TrackingDaemonSets, id, spec, event, restart.PodContainerRestart)
+}
+
+func (mt *multitracker) daemonsetStatusReport(id ResourceID, spec MultitrackSpec, feed daemonset.Feed, status daemonset.DaemonSetStatus) error {
 	if debug() {
 		fmt.Printf("-- daemonsetStatusReport %#v %#v\n", spec, status)
 	}
 
-	mt.DaemonSetsStatuses[spec.ResourceName] = status
+	mt.DaemonSetsStatuses[id] = status
+	mt.handleResourceProgress(id)
+	mt.printer.ResourceStatus(mt.resourceEvent(id, display.StatusReportEvent).WithStatus(status))
 
-	return nil
+	return mt.handleResourcePodsStatuses(mt.TrackingDaemonSets, id, spec, status.Pods)
 }
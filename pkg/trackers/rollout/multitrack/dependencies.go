@@ -0,0 +1,197 @@
+package multitrack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flant/kubedog/pkg/display"
+)
+
+// specDependencies returns ids of DependsOn resources, spec should be validated with ValidateSpec
+func specDependencies(spec MultitrackSpec) []ResourceID {
+	dependencies := []ResourceID{}
+	for _, dependency := range spec.DependsOn {
+		kind, name, err := ParseResourceKindName(dependency)
+		if err != nil {
+			panic(fmt.Sprintf("bad dependency %q: %s", dependency, err))
+		}
+		dependencies = append(dependencies, ResourceID{Namespace: spec.Namespace, Kind: kind, Name: name})
+	}
+	return dependencies
+}
+
+// validateDependencies checks that dependencies of resources are tracked and have no cycles
+func validateDependencies(dependencies map[ResourceID][]ResourceID, order []ResourceID, allowUntracked bool) error {
+	for _, id := range order {
+		for _, dependencyID := range dependencies[id] {
+			if dependencyID == id {
+				return fmt.Errorf("%s: depends on itself", id)
+			}
+			if _, hasKey := dependencies[dependencyID]; !hasKey && !allowUntracked {
+				return fmt.Errorf("%s: depends on %s, which is not tracked", id, dependencyID.KindName())
+			}
+		}
+	}
+
+	const (
+		notVisited = iota
+		inProgress
+		visited
+	)
+	visitState := make(map[ResourceID]int)
+	path := []ResourceID{}
+
+	var visit func(id ResourceID) error
+	visit = func(id ResourceID) error {
+		switch visitState[id] {
+		case visited:
+			return nil
+		case inProgress:
+			cycle := []string{}
+			for i := range path {
+				if path[i] == id {
+					for _, cycleID := range path[i:] {
+						cycle = append(cycle, cycleID.KindName())
+					}
+					break
+				}
+			}
+			cycle = append(cycle, id.KindName())
+			return fmt.Errorf("%s: dependency cycle: %s", id, strings.Join(cycle, " -> "))
+		}
+
+		visitState[id] = inProgress
+		path = append(path, id)
+
+		for _, dependencyID := range dependencies[id] {
+			if err := visit(dependencyID); err != nil {
+				return err
+			}
+		}
+
+		path = path[:len(path)-1]
+		visitState[id] = visited
+
+		return nil
+	}
+
+	for _, id := range order {
+		if err := visit(id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (mt *multitracker) areDependenciesReady(id ResourceID) bool {
+	return len(mt.notReadyDependencies(id)) == 0
+}
+
+// notReadyDependencies returns names of dependencies of the resource which are not ready yet
+func (mt *multitracker) notReadyDependencies(id ResourceID) []string {
+	res := []string{}
+	for _, dependencyID := range mt.resourcesDependencies[id] {
+		if mt.results[dependencyID].State != ResourceReady {
+			res = append(res, mt.resourceName(dependencyID))
+		}
+	}
+	return res
+}
+
+// handleDependencyReady starts tracking of resources waiting for the ready resource
+// and makes ready resources which ready verdict is waiting for it
+func (mt *multitracker) handleDependencyReady(readyID ResourceID) {
+	for _, id := range mt.resourcesOrder {
+		if !mt.dependsOn(id, readyID) || !mt.areDependenciesReady(id) {
+			continue
+		}
+
+		if mt.waitingResources[id] {
+			delete(mt.waitingResources, id)
+			mt.printer.Message(mt.resourceEvent(id, display.MessageEvent).WithMessage("dependencies are ready"), fmt.Sprintf("# %s dependencies are ready", mt.resourceName(id)))
+			mt.runTracker(id)
+			continue
+		}
+
+		states := mt.resourcesStates(id.Kind)
+		if state, hasKey := states[id]; hasKey && state.IsReadyPendingDependencies && !state.IsFailed {
+			mt.printer.Message(mt.resourceEvent(id, display.MessageEvent).WithMessage("dependencies are ready"), fmt.Sprintf("# %s dependencies are ready", mt.resourceName(id)))
+			if mt.finishReadyResource(states, id, mt.resourcesSpecs(id.Kind)[id]) {
+				mt.stopTracker(id)
+			}
+		}
+	}
+}
+
+// failDependentResources fails resources depending on the failed resource
+func (mt *multitracker) failDependentResources(failedID ResourceID) {
+	for _, id := range mt.resourcesOrder {
+		if !mt.dependsOn(id, failedID) {
+			continue
+		}
+
+		if state, hasKey := mt.resourcesStates(id.Kind)[id]; !hasKey || state.IsFailed {
+			continue
+		}
+
+		mt.failDependentResource(id, fmt.Sprintf("dependency %s failed", mt.resourceName(failedID)))
+	}
+}
+
+// failDependentResource fails resource because of its dependencies according to the resource FailMode.
+// Resource waiting for dependencies is failed right away: it is not tracked and cannot recover.
+func (mt *multitracker) failDependentResource(id ResourceID, reason string) {
+	if !mt.waitingResources[id] {
+		mt.failResourceImmediately(id, reason)
+		return
+	}
+
+	states := mt.resourcesStates(id.Kind)
+	spec := mt.resourcesSpecs(id.Kind)[id]
+	state := states[id]
+
+	mt.printer.Message(mt.resourceEvent(id, display.FailedEvent).WithMessage(reason), fmt.Sprintf("# %s failed: %s", mt.resourceName(id), reason))
+
+	state.LastFailureReason = reason
+	mt.addResourceFailure(id, "", reason)
+
+	delete(mt.waitingResources, id)
+
+	if spec.FailMode == IgnoreAndContinueDeployProcess {
+		delete(states, id)
+		mt.results[id].State = ResourceIgnored
+	} else {
+		state.IsFailed = true
+	}
+
+	mt.failDependentResources(id)
+}
+
+// failWaitingResources fails resources which tracking has not been started when all trackers are done:
+// their dependencies have finished without ready or failed verdict (e.g. tracked pod is deleted) and will not become ready
+func (mt *multitracker) failWaitingResources() {
+	for _, id := range mt.resourcesOrder {
+		if mt.waitingResources[id] {
+			mt.failDependentResource(id, fmt.Sprintf("dependency finished without becoming ready: %s", strings.Join(mt.notReadyDependencies(id), ", ")))
+		}
+	}
+}
+
+// isWaitingForDependencies returns true when resource tracking or its ready verdict waits for dependencies
+func (mt *multitracker) isWaitingForDependencies(id ResourceID) bool {
+	if mt.waitingResources[id] {
+		return true
+	}
+	state, hasKey := mt.resourcesStates(id.Kind)[id]
+	return hasKey && state.IsReadyPendingDependencies && !state.IsFailed
+}
+
+func (mt *multitracker) dependsOn(id, dependencyID ResourceID) bool {
+	for _, depID := range mt.resourcesDependencies[id] {
+		if depID == dependencyID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package multitrack
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/flant/kubedog/pkg/display"
+)
+
+func TestValidateSpecsDependencies(t *testing.T) {
+	tests := []struct {
+		name  string
+		specs MultitrackSpecs
+		err   string
+	}{
+		{
+			name: "chain",
+			specs: MultitrackSpecs{
+				Jobs:        []MultitrackSpec{{ResourceName: "migrate", Namespace: "default"}},
+				Deployments: []MultitrackSpec{{ResourceName: "api", Namespace: "default", DependsOn: []string{"job/migrate"}}},
+				Pods:        []MultitrackSpec{{ResourceName: "smoke", Namespace: "default", DependsOn: []string{"deployment/api", "jobs/migrate"}}},
+			},
+		},
+		{
+			name: "same name in other namespace is not tracked",
+			specs: MultitrackSpecs{
+				Jobs:        []MultitrackSpec{{ResourceName: "migrate", Namespace: "db"}},
+				Deployments: []MultitrackSpec{{ResourceName: "api", Namespace: "default", DependsOn: []string{"job/migrate"}}},
+			},
+			err: "deploy/api in ns/default: depends on job/migrate, which is not tracked",
+		},
+		{
+			name: "untracked dependency with label selector spec",
+			specs: MultitrackSpecs{
+				Jobs:        []MultitrackSpec{{LabelSelector: "app=migrate", Namespace: "default"}},
+				Deployments: []MultitrackSpec{{ResourceName: "api", Namespace: "default", DependsOn: []string{"job/migrate"}}},
+			},
+		},
+		{
+			name: "bad dependency",
+			specs: MultitrackSpecs{
+				Deployments: []MultitrackSpec{{ResourceName: "api", Namespace: "default", DependsOn: []string{"migrate"}}},
+			},
+			err: "bad dependsOn",
+		},
+		{
+			name: "itself",
+			specs: MultitrackSpecs{
+				Deployments: []MultitrackSpec{{ResourceName: "api", Namespace: "default", DependsOn: []string{"deploy/api"}}},
+			},
+			err: "depends on itself",
+		},
+		{
+			name: "cycle",
+			specs: MultitrackSpecs{
+				Deployments: []MultitrackSpec{
+					{ResourceName: "api", Namespace: "default", DependsOn: []string{"sts/db"}},
+					{ResourceName: "worker", Namespace: "default", DependsOn: []string{"deploy/api"}},
+				},
+				StatefulSets: []MultitrackSpec{{ResourceName: "db", Namespace: "default", DependsOn: []string{"deploy/worker"}}},
+			},
+			err: "dependency cycle: deploy/api -> sts/db -> deploy/worker -> deploy/api",
+		},
+		{
+			name: "cycle not including the first resource",
+			specs: MultitrackSpecs{
+				Pods: []MultitrackSpec{{ResourceName: "smoke", Namespace: "default", DependsOn: []string{"deploy/api"}}},
+				Deployments: []MultitrackSpec{
+					{ResourceName: "api", Namespace: "default", DependsOn: []string{"deploy/worker"}},
+					{ResourceName: "worker", Namespace: "default", DependsOn: []string{"deploy/api"}},
+				},
+			},
+			err: "dependency cycle: deploy/api -> deploy/worker -> deploy/api",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checkError(t, ValidateSpecs(test.specs), test.err)
+		})
+	}
+}
+
+func TestFailWaitingResources(t *testing.T) {
+	db := ResourceID{Namespace: "default", Kind: "po", Name: "db"}
+	api := ResourceID{Namespace: "default", Kind: "deploy", Name: "api"}
+	worker := ResourceID{Namespace: "default", Kind: "deploy", Name: "worker"}
+	migrate := ResourceID{Namespace: "default", Kind: "job", Name: "migrate"}
+
+	out := &bytes.Buffer{}
+	mt := &multitracker{
+		PodsSpecs:        map[ResourceID]MultitrackSpec{db: {ResourceName: "db", FailMode: FailWholeDeployProcessImmediately}},
+		DeploymentsSpecs: map[ResourceID]MultitrackSpec{api: {ResourceName: "api", FailMode: FailWholeDeployProcessImmediately}, worker: {ResourceName: "worker", FailMode: IgnoreAndContinueDeployProcess}},
+		JobsSpecs:        map[ResourceID]MultitrackSpec{migrate: {ResourceName: "migrate", FailMode: FailWholeDeployProcessImmediately}},
+
+		TrackingPods:        map[ResourceID]*multitrackerResourceState{db: {}},
+		TrackingDeployments: map[ResourceID]*multitrackerResourceState{api: {}, worker: {}},
+		TrackingJobs:        map[ResourceID]*multitrackerResourceState{migrate: {}},
+
+		results: map[ResourceID]*ResourceResult{db: {ID: db}, api: {ID: api}, worker: {ID: worker}, migrate: {ID: migrate}},
+
+		resourcesOrder: []ResourceID{db, api, worker, migrate},
+		// Tracker of po/db has finished without ready or failed verdict, e.g. the pod has been deleted
+		resourcesDependencies: map[ResourceID][]ResourceID{api: {db}, worker: {db}, migrate: {api}},
+		waitingResources:      map[ResourceID]bool{api: true, worker: true, migrate: true},
+
+		printer: display.NewTextPrinter(out),
+	}
+
+	mt.failWaitingResources()
+
+	if len(mt.waitingResources) != 0 {
+		t.Errorf("expected no waiting resources, got %v", mt.waitingResources)
+	}
+	if !mt.hasFailedTrackingResources() {
+		t.Errorf("expected failed tracking resources")
+	}
+
+	for _, test := range []struct {
+		id     ResourceID
+		state  ResourceState
+		failed bool
+		reason string
+	}{
+		{id: db},
+		{id: api, failed: true, reason: "dependency finished without becoming ready: po/db"},
+		{id: worker, state: ResourceIgnored, reason: "dependency finished without becoming ready: po/db"},
+		{id: migrate, failed: true, reason: "dependency deploy/api failed"},
+	} {
+		state, hasKey := mt.resourcesStates(test.id.Kind)[test.id]
+		if failed := hasKey && state.IsFailed; failed != test.failed {
+			t.Errorf("%s: expected failed %v, got %v", test.id, test.failed, failed)
+		}
+		if result := mt.results[test.id]; result.State != test.state {
+			t.Errorf("%s: expected result state %q, got %q", test.id, test.state, result.State)
+		}
+		if test.reason == "" {
+			continue
+		}
+		if reasons := mt.results[test.id].FailureReasons; len(reasons) != 1 || reasons[0] != test.reason {
+			t.Errorf("%s: expected failure reason %q, got %q", test.id, test.reason, reasons)
+		}
+		if expected := "# " + test.id.KindName() + " failed: " + test.reason; !strings.Contains(out.String(), expected) {
+			t.Errorf("%s: expected %q in output:\n%s", test.id, expected, out.String())
+		}
+	}
+}
@@ -11,107 +11,136 @@ import (
 
 func (mt *multitracker) TrackDeployment(kube kubernetes.Interface, spec MultitrackSpec, opts MultitrackOptions) error {
 	feed := deployment.NewFeed()
+	id := ResourceID{Namespace: spec.Namespace, Kind: "deploy", Name: spec.ResourceName}
 
 	feed.OnAdded(func(ready bool) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.deploymentAdded(spec, feed, ready)
+		if err := mt.applyObjectAnnotations(id, &spec, feed.GetStatus().Annotations); err != nil {
+			return err
+		}
+		return mt.deploymentAdded(id, spec, feed, ready)
 	})
 	feed.OnReady(func() error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.deploymentReady(spec, feed)
+		return mt.deploymentReady(id, spec, feed)
 	})
 	feed.OnFailed(func(reason string) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.deploymentFailed(spec, feed, reason)
+		return mt.deploymentFailed(id, spec, feed, reason)
 	})
 	feed.OnEventMsg(func(msg string) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.deploymentEventMsg(spec, feed, msg)
+		return mt.deploymentEventMsg(id, spec, feed, msg)
 	})
 	feed.OnAddedReplicaSet(func(rs replicaset.ReplicaSet) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.deploymentAddedReplicaSet(spec, feed, rs)
+		return mt.deploymentAddedReplicaSet(id, spec, feed, rs)
 	})
 	feed.OnAddedPod(func(pod replicaset.ReplicaSetPod) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.deploymentAddedPod(spec, feed, pod)
+		return mt.deploymentAddedPod(id, spec, feed, pod)
 	})
 	feed.OnPodError(func(podError replicaset.ReplicaSetPodError) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.deploymentPodError(spec, feed, podError)
+		return mt.deploymentPodError(id, spec, feed, podError)
 	})
 	feed.OnPodLogChunk(func(chunk *replicaset.ReplicaSetPodLogChunk) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.deploymentPodLogChunk(spec, feed, chunk)
+		return mt.deploymentPodLogChunk(id, spec, feed, chunk)
+	})
+	feed.OnPodContainerTermination(func(termination replicaset.ReplicaSetPodContainerTermination) error {
+		mt.handlerMux.Lock()
+		defer mt.handlerMux.Unlock()
+		return mt.deploymentPodContainerTermination(id, spec, feed, termination)
+	})
+	feed.OnPodUnschedulable(func(failure replicaset.ReplicaSetPodSchedulingFailure) error {
+		mt.handlerMux.Lock()
+		defer mt.handlerMux.Unlock()
+		return mt.deploymentPodUnschedulable(id, spec, feed, failure)
+	})
+	feed.OnPodContainerRestart(func(restart replicaset.ReplicaSetPodContainerRestart) error {
+		mt.handlerMux.Lock()
+		defer mt.handlerMux.Unlock()
+		return mt.deploymentPodContainerRestart(id, spec, feed, restart)
 	})
 	feed.OnStatusReport(func(status deployment.DeploymentStatus) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.deploymentStatusReport(spec, feed, status)
+		return mt.deploymentStatusReport(id, spec, feed, status)
 	})
 
 	return feed.Track(spec.ResourceName, spec.Namespace, kube, opts.Options)
 }
 
-func (mt *multitracker) deploymentAdded(spec MultitrackSpec, feed deployment.Feed, ready bool) error {
+func (mt *multitracker) deploymentAdded(id ResourceID, spec MultitrackSpec, feed deployment.Feed, ready bool) error {
 	if debug() {
 		fmt.Printf("-- deploymentAdded %#v %#v\n", spec, ready)
 	}
 
+	mt.DeploymentsSpecs[id] = spec
+
 	if ready {
-		mt.DeploymentsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.DeploymentsStatuses[id] = feed.GetStatus()
 
-		display.OutF("# deploy/%s appears to be READY\n", spec.ResourceName)
+		mt.printer.Message(mt.resourceEvent(id, display.ReadyEvent), fmt.Sprintf("# %s appears to be READY", mt.resourceName(id)))
 
-		return mt.handleResourceReadyCondition(mt.TrackingDeployments, spec)
+		return mt.handleResourceReadyCondition(mt.TrackingDeployments, id, spec)
 	}
 
-	display.OutF("# deploy/%s added\n", spec.ResourceName)
+	mt.printer.Message(mt.resourceEvent(id, display.AddedEvent), fmt.Sprintf("# %s added", mt.resourceName(id)))
 
 	return nil
 }
 
-func (mt *multitracker) deploymentReady(spec MultitrackSpec, feed deployment.Feed) error {
+func (mt *multitracker) deploymentReady(id ResourceID, spec MultitrackSpec, feed deployment.Feed) error {
 	if debug() {
 		fmt.Printf("-- deploymentReady %#v\n", spec)
 	}
 
-	mt.DeploymentsStatuses[spec.ResourceName] = feed.GetStatus()
+	if !mt.isResourceTracked(mt.TrackingDeployments, id) {
+		return nil
+	}
+
+	mt.DeploymentsStatuses[id] = feed.GetStatus()
 
-	display.OutF("# deploy/%s become READY\n", spec.ResourceName)
+	mt.printer.Message(mt.resourceEvent(id, display.ReadyEvent), fmt.Sprintf("# %s become READY", mt.resourceName(id)))
 
-	return mt.handleResourceReadyCondition(mt.TrackingDeployments, spec)
+	return mt.handleResourceReadyCondition(mt.TrackingDeployments, id, spec)
 }
 
-func (mt *multitracker) deploymentFailed(spec MultitrackSpec, feed deployment.Feed, reason string) error {
+func (mt *multitracker) deploymentFailed(id ResourceID, spec MultitrackSpec, feed deployment.Feed, reason string) error {
 	if debug() {
 		fmt.Printf("-- deploymentFailed %#v %#v\n", spec, reason)
 	}
 
-	display.OutF("# deploy/%s FAIL: %s\n", spec.ResourceName, reason)
+	if !mt.isResourceTracked(mt.TrackingDeployments, id) {
+		return nil
+	}
+
+	mt.printer.Message(mt.resourceEvent(id, display.FailedEvent).WithMessage(reason), fmt.Sprintf("# %s FAIL: %s", mt.resourceName(id), reason))
 
-	return mt.handleResourceFailure(mt.TrackingDeployments, spec, reason)
+	return mt.handleResourceFailure(mt.TrackingDeployments, id, spec, "", reason)
 }
 
-func (mt *multitracker) deploymentEventMsg(spec MultitrackSpec, feed deployment.Feed, msg string) error {
+func (mt *multitracker) deploymentEventMsg(id ResourceID, spec MultitrackSpec, feed deployment.Feed, msg string) error {
 	if debug() {
 		fmt.Printf("-- deploymentEventMsg %#v %#v\n", spec, msg)
 	}
 
-	display.OutF("# deploy/%s event: %s\n", spec.ResourceName, msg)
+	mt.printer.Message(mt.resourceEvent(id, display.EventMsgEvent).WithMessage(msg), fmt.Sprintf("# %s event: %s", mt.resourceName(id), msg))
 
 	return nil
 }
 
-func (mt *multitracker) deploymentAddedReplicaSet(spec MultitrackSpec, feed deployment.Feed, rs replicaset.ReplicaSet) error {
+func (mt *multitracker) deploymentAddedReplicaSet(id ResourceID, spec MultitrackSpec, feed deployment.Feed, rs replicaset.ReplicaSet) error {
 	if debug() {
 		fmt.Printf("-- deploymentAddedReplicaSet %#v %#v\n", spec, rs)
 	}
@@ -119,12 +148,12 @@ func (mt *multitracker) deploymentAddedReplicaSet(spec MultitrackSpec, feed depl
 	if !rs.IsNew {
 		return nil
 	}
-	display.OutF("# deploy/%s rs/%s added\n", spec.ResourceName, rs.Name)
+	mt.printer.Message(mt.resourceEvent(id, display.AddedReplicaSetEvent).WithReplicaSet(rs.Name), fmt.Sprintf("# %s rs/%s added", mt.resourceName(id), rs.Name))
 
 	return nil
 }
 
-func (mt *multitracker) deploymentAddedPod(spec MultitrackSpec, feed deployment.Feed, pod replicaset.ReplicaSetPod) error {
+func (mt *multitracker) deploymentAddedPod(id ResourceID, spec MultitrackSpec, feed deployment.Feed, pod replicaset.ReplicaSetPod) error {
 	if debug() {
 		fmt.Printf("-- deploymentAddedPod %#v %#v\n", spec, pod)
 	}
@@ -132,28 +161,33 @@ func (mt *multitracker) deploymentAddedPod(spec MultitrackSpec, feed deployment.
 	if !pod.ReplicaSet.IsNew {
 		return nil
 	}
-	display.OutF("# deploy/%s po/%s added\n", spec.ResourceName, pod.Name)
+	mt.printer.Message(mt.resourceEvent(id, display.AddedPodEvent).WithReplicaSet(pod.ReplicaSet.Name).WithPod(pod.Name, ""), fmt.Sprintf("# %s po/%s added", mt.resourceName(id), pod.Name))
 
 	return nil
 }
 
-func (mt *multitracker) deploymentPodError(spec MultitrackSpec, feed deployment.Feed, podError replicaset.ReplicaSetPodError) error {
+func (mt *multitracker) deploymentPodError(id ResourceID, spec MultitrackSpec, feed deployment.Feed, podError replicaset.ReplicaSetPodError) error {
 	if debug() {
 		fmt.Printf("-- deploymentPodError %#v %#v\n", spec, podError)
 	}
 
+	if !mt.isResourceTracked(mt.TrackingDeployments, id) {
+		return nil
+	}
+
 	if !podError.ReplicaSet.IsNew {
 		return nil
 	}
 
 	reason := fmt.Sprintf("po/%s container/%s error: %s", podError.PodName, podError.ContainerName, podError.Message)
 
-	display.OutF("# deploy/%s %s\n", spec.ResourceName, reason)
+	event := mt.resourceEvent(id, display.PodErrorEvent).WithReplicaSet(podError.ReplicaSet.Name).WithPod(podError.PodName, podError.ContainerName).WithMessage(podError.Message)
+	mt.printer.Message(event, fmt.Sprintf("# %s %s", mt.resourceName(id), reason))
 
-	return mt.handleResourceFailure(mt.TrackingDeployments, spec, reason)
+	return mt.handleResourceFailure(mt.TrackingDeployments, id, spec, podError.PodName, reason)
 }
 
-func (mt *multitracker) deploymentPodLogChunk(spec MultitrackSpec, feed deployment.Feed, chunk *replicaset.ReplicaSetPodLogChunk) error {
+func (mt *multitracker) deploymentPodLogChunk(id ResourceID, spec MultitrackSpec, feed deployment.Feed, chunk *replicaset.ReplicaSetPodLogChunk) error {
 	if debug() {
 		fmt.Printf("-- deploymentPodLogChunk %#v %#v\n", spec, chunk)
 	}
@@ -162,18 +196,71 @@ func (mt *multitracker) deploymentPodLogChunk(spec MultitrackSpec, feed deployme
 		return nil
 	}
 
-	header := fmt.Sprintf("deploy/%s %s", spec.ResourceName, podContainerLogChunkHeader(chunk.PodName, chunk.ContainerLogChunk))
-	displayContainerLogChunk(header, spec, chunk.ContainerLogChunk)
+	header := fmt.Sprintf("%s %s", mt.resourceName(id), podContainerLogChunkHeader(chunk.PodName, chunk.ContainerLogChunk))
+	return mt.handleContainerLogChunk(mt.TrackingDeployments, id, spec, header, chunk.PodName, chunk.ContainerLogChunk, mt.DeploymentsStatuses[id].Pods[chunk.PodName])
+}
+
+func (mt *multitracker) deploymentPodContainerTermination(id ResourceID, spec MultitrackSpec, feed deployment.Feed, termination replicaset.ReplicaSetPodContainerTermination) error {
+	if debug() {
+		fmt.Printf("-- deploymentPodContainerTermination %#v %#v\n", spec, termination)
+	}
+
+	if !mt.isResourceTracked(mt.TrackingDeployments, id) {
+		return nil
+	}
+
+	if !termination.ReplicaSet.IsNew {
+		return nil
+	}
+
+	event := mt.resourceEvent(id, display.ContainerTerminatedEvent).WithReplicaSet(termination.ReplicaSet.Name).WithPod(termination.PodName, termination.ContainerName)
+	header := fmt.Sprintf("%s po/%s %s (terminated)", mt.resourceName(id), termination.PodName, termination.ContainerName)
+	return mt.handleContainerTermination(mt.TrackingDeployments, id, event, header, termination.PodContainerTermination)
+}
+
+func (mt *multitracker) deploymentPodUnschedulable(id ResourceID, spec MultitrackSpec, feed deployment.Feed, failure replicaset.ReplicaSetPodSchedulingFailure) error {
+	if debug() {
+		fmt.Printf("-- deploymentPodUnschedulable %#v %#v\n", spec, failure)
+	}
+
+	if !mt.isResourceTracked(mt.TrackingDeployments, id) {
+		return nil
+	}
+
+	if !failure.ReplicaSet.IsNew {
+		return nil
+	}
+
+	mt.handlePodUnschedulable(id, mt.resourceEvent(id, display.UnschedulableEvent).WithReplicaSet(failure.ReplicaSet.Name), failure.PodName, failure.PodSchedulingFailure.SchedulingFailure)
 
 	return nil
 }
 
-func (mt *multitracker) deploymentStatusReport(spec MultitrackSpec, feed deployment.Feed, status deployment.DeploymentStatus) error {
+func (mt *multitracker) deploymentPodContainerRestart(id ResourceID, spec MultitrackSpec, feed deployment.Feed, restart replicaset.ReplicaSetPodContainerRestart) error {
+	if debug() {
+		fmt.Printf("-- deploymentPodContainerRestart %#v %#v\n", spec, restart)
+	}
+
+	if !mt.isResourceTracked(mt.TrackingDeployments, id) {
+		return nil
+	}
+
+	if !restart.ReplicaSet.IsNew {
+		return nil
+	}
+
+	event := mt.resourceEvent(id, display.ContainerRestartedEvent).WithReplicaSet(restart.ReplicaSet.Name).WithPod(restart.PodName, restart.ContainerName)
+	return mt.handleContainerRestart(mt.TrackingDeployments, id, spec, event, restart.PodContainerRestart)
+}
+
+func (mt *multitracker) deploymentStatusReport(id ResourceID, spec MultitrackSpec, feed deployment.Feed, status deployment.DeploymentStatus) error {
 	if debug() {
 		fmt.Printf("-- deploymentStatusReport %#v %#v\n", spec, status)
 	}
 
-	mt.DeploymentsStatuses[spec.ResourceName] = status
+	mt.DeploymentsStatuses[id] = status
+	mt.handleResourceProgress(id)
+	mt.printer.ResourceStatus(mt.resourceEvent(id, display.StatusReportEvent).WithStatus(status))
 
-	return nil
+	return mt.handleResourcePodsStatuses(mt.TrackingDeployments, id, spec, status.Pods)
 }
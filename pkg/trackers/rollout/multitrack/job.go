@@ -11,132 +11,203 @@ import (
 
 func (mt *multitracker) TrackJob(kube kubernetes.Interface, spec MultitrackSpec, opts MultitrackOptions) error {
 	feed := job.NewFeed()
+	id := ResourceID{Namespace: spec.Namespace, Kind: "job", Name: spec.ResourceName}
 
 	feed.OnAdded(func() error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.jobAdded(spec, feed)
+		if err := mt.applyObjectAnnotations(id, &spec, feed.GetStatus().Annotations); err != nil {
+			return err
+		}
+		return mt.jobAdded(id, spec, feed)
 	})
 	feed.OnSucceeded(func() error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.jobSucceeded(spec, feed)
+		return mt.jobSucceeded(id, spec, feed)
 	})
 	feed.OnFailed(func(reason string) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.jobFailed(spec, feed, reason)
+		return mt.jobFailed(id, spec, feed, reason)
 	})
 	feed.OnEventMsg(func(msg string) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.jobEventMsg(spec, feed, msg)
+		return mt.jobEventMsg(id, spec, feed, msg)
 	})
 	feed.OnAddedPod(func(podName string) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.jobAddedPod(spec, feed, podName)
+		return mt.jobAddedPod(id, spec, feed, podName)
 	})
 	feed.OnPodLogChunk(func(chunk *pod.PodLogChunk) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.jobPodLogChunk(spec, feed, chunk)
+		return mt.jobPodLogChunk(id, spec, feed, chunk)
 	})
 	feed.OnPodError(func(podError pod.PodError) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.jobPodError(spec, feed, podError)
+		return mt.jobPodError(id, spec, feed, podError)
+	})
+	feed.OnPodContainerTermination(func(termination pod.PodContainerTermination) error {
+		mt.handlerMux.Lock()
+		defer mt.handlerMux.Unlock()
+		return mt.jobPodContainerTermination(id, spec, feed, termination)
+	})
+	feed.OnPodUnschedulable(func(failure pod.PodSchedulingFailure) error {
+		mt.handlerMux.Lock()
+		defer mt.handlerMux.Unlock()
+		return mt.jobPodUnschedulable(id, spec, feed, failure)
+	})
+	feed.OnPodContainerRestart(func(restart pod.PodContainerRestart) error {
+		mt.handlerMux.Lock()
+		defer mt.handlerMux.Unlock()
+		return mt.jobPodContainerRestart(id, spec, feed, restart)
 	})
 	feed.OnStatusReport(func(status job.JobStatus) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.jobStatusReport(spec, feed, status)
+		return mt.jobStatusReport(id, spec, feed, status)
 	})
 
 	return feed.Track(spec.ResourceName, spec.Namespace, kube, opts.Options)
 }
 
-func (mt *multitracker) jobAdded(spec MultitrackSpec, feed job.Feed) error {
+func (mt *multitracker) jobAdded(id ResourceID, spec MultitrackSpec, feed job.Feed) error {
 	if debug() {
 		fmt.Printf("-- jobAdded %#v\n", spec)
 	}
 
-	display.OutF("# job/%s added\n", spec.ResourceName)
+	mt.JobsSpecs[id] = spec
+
+	mt.printer.Message(mt.resourceEvent(id, display.AddedEvent), fmt.Sprintf("# %s added", mt.resourceName(id)))
 
 	return nil
 }
 
-func (mt *multitracker) jobSucceeded(spec MultitrackSpec, feed job.Feed) error {
+func (mt *multitracker) jobSucceeded(id ResourceID, spec MultitrackSpec, feed job.Feed) error {
 	if debug() {
 		fmt.Printf("-- jobSucceeded %#v\n", spec)
 	}
 
-	mt.JobsStatuses[spec.ResourceName] = feed.GetStatus()
+	mt.JobsStatuses[id] = feed.GetStatus()
 
-	display.OutF("# job/%s succeeded\n", spec.ResourceName)
+	mt.printer.Message(mt.resourceEvent(id, display.SucceededEvent), fmt.Sprintf("# %s succeeded", mt.resourceName(id)))
 
-	return mt.handleResourceReadyCondition(mt.TrackingJobs, spec)
+	return mt.handleResourceReadyCondition(mt.TrackingJobs, id, spec)
 }
 
-func (mt *multitracker) jobFailed(spec MultitrackSpec, feed job.Feed, reason string) error {
+func (mt *multitracker) jobFailed(id ResourceID, spec MultitrackSpec, feed job.Feed, reason string) error {
 	if debug() {
 		fmt.Printf("-- jobFailed %#v %#v\n", spec, reason)
 	}
 
-	fmt.Fprintf(display.Out, "# job/%s failed: %s\n", spec.ResourceName, reason)
+	if !mt.isResourceTracked(mt.TrackingJobs, id) {
+		return nil
+	}
+
+	mt.printer.Message(mt.resourceEvent(id, display.FailedEvent).WithMessage(reason), fmt.Sprintf("# %s failed: %s", mt.resourceName(id), reason))
 
-	return mt.handleResourceFailure(mt.TrackingJobs, spec, reason)
+	return mt.handleResourceFailure(mt.TrackingJobs, id, spec, "", reason)
 }
 
-func (mt *multitracker) jobEventMsg(spec MultitrackSpec, feed job.Feed, msg string) error {
+func (mt *multitracker) jobEventMsg(id ResourceID, spec MultitrackSpec, feed job.Feed, msg string) error {
 	if debug() {
 		fmt.Printf("-- jobEventMsg %#v %#v\n", spec, msg)
 	}
 
-	display.OutF("# job/%s event: %s\n", spec.ResourceName, msg)
+	mt.printer.Message(mt.resourceEvent(id, display.EventMsgEvent).WithMessage(msg), fmt.Sprintf("# %s event: %s", mt.resourceName(id), msg))
 
 	return nil
 }
 
-func (mt *multitracker) jobAddedPod(spec MultitrackSpec, feed job.Feed, podName string) error {
+func (mt *multitracker) jobAddedPod(id ResourceID, spec MultitrackSpec, feed job.Feed, podName string) error {
 	if debug() {
 		fmt.Printf("-- jobAddedPod %#v %#v\n", spec, podName)
 	}
 
-	display.OutF("# job/%s po/%s added\n", spec.ResourceName, podName)
+	mt.printer.Message(mt.resourceEvent(id, display.AddedPodEvent).WithPod(podName, ""), fmt.Sprintf("# %s po/%s added", mt.resourceName(id), podName))
 
 	return nil
 }
 
-func (mt *multitracker) jobPodLogChunk(spec MultitrackSpec, feed job.Feed, chunk *pod.PodLogChunk) error {
+func (mt *multitracker) jobPodLogChunk(id ResourceID, spec MultitrackSpec, feed job.Feed, chunk *pod.PodLogChunk) error {
 	if debug() {
 		fmt.Printf("-- jobPodLogChunk %#v %#v\n", spec, chunk)
 	}
 
-	header := fmt.Sprintf("jobs/%s %s", spec.ResourceName, podContainerLogChunkHeader(chunk.PodName, chunk.ContainerLogChunk))
-	displayContainerLogChunk(header, spec, chunk.ContainerLogChunk)
-
-	return nil
+	header := fmt.Sprintf("%s %s", mt.resourceName(id), podContainerLogChunkHeader(chunk.PodName, chunk.ContainerLogChunk))
+	return mt.handleContainerLogChunk(mt.TrackingJobs, id, spec, header, chunk.PodName, chunk.ContainerLogChunk, mt.JobsStatuses[id].Pods[chunk.PodName])
 }
 
-func (mt *multitracker) jobPodError(spec MultitrackSpec, feed job.Feed, podError pod.PodError) error {
+func (mt *multitracker) jobPodError(id ResourceID, spec MultitrackSpec, feed job.Feed, podError pod.PodError) error {
 	if debug() {
 		fmt.Printf("-- jobPodError %#v %#v\n", spec, podError)
 	}
 
+	if !mt.isResourceTracked(mt.TrackingJobs, id) {
+		return nil
+	}
+
 	reason := fmt.Sprintf("po/%s container/%s error: %s", podError.PodName, podError.ContainerName, podError.Message)
 
-	fmt.Fprintf(display.Out, "# job/%s %s\n", spec.ResourceName, reason)
+	event := mt.resourceEvent(id, display.PodErrorEvent).WithPod(podError.PodName, podError.ContainerName).WithMessage(podError.Message)
+	mt.printer.Message(event, fmt.Sprintf("# %s %s", mt.resourceName(id), reason))
 
-	return mt.handleResourceFailure(mt.TrackingJobs, spec, reason)
+	return mt.handleResourceFailure(mt.TrackingJobs, id, spec, podError.PodName, reason)
 }
 
-func (mt *multitracker) jobStatusReport(spec MultitrackSpec, feed job.Feed, status job.JobStatus) error {
+func (mt *multitracker) jobPodContainerTermination(id ResourceID, spec MultitrackSpec, feed job.Feed, termination pod.PodContainerTermination) error {
 	if debug() {
-		fmt.Printf("-- jobStatusReport %#v %#v\n", spec, status)
+		fmt.Printf("-- jobPodContainerTermination %#v %#v\n", spec, termination)
+	}
+
+	if !mt.isResourceTracked(mt.TrackingJobs, id) {
+		return nil
+	}
+
+	event := mt.resourceEvent(id, display.ContainerTerminatedEvent).WithPod(termination.PodName, termination.ContainerName)
+	header := fmt.Sprintf("%s po/%s %s (terminated)", mt.resourceName(id), termination.PodName, termination.ContainerName)
+	return mt.handleContainerTermination(mt.TrackingJobs, id, event, header, termination)
+}
+
+func (mt *multitracker) jobPodUnschedulable(id ResourceID, spec MultitrackSpec, feed job.Feed, failure pod.PodSchedulingFailure) error {
+	if debug() {
+		fmt.Printf("-- jobPodUnschedulable %#v %#v\n", spec, failure)
+	}
+
+	if !mt.isResourceTracked(mt.TrackingJobs, id) {
+		return nil
 	}
 
-	mt.JobsStatuses[spec.ResourceName] = status
+	mt.handlePodUnschedulable(id, mt.resourceEvent(id, display.UnschedulableEvent), failure.PodName, failure.SchedulingFailure)
 
 	return nil
 }
+
+func (mt *multitracker) jobPodContainerRestart(id ResourceID, spec MultitrackSpec, feed job.Feed, restart pod.PodContainerRestart) error {
+	if debug() {
+		fmt.Printf("-- jobPodContainerRestart %#v %#v\n", spec, restart)
+	}
+
+	if !mt.isResourceTracked(mt.TrackingJobs, id) {
+		return nil
+	}
+
+	event := mt.resourceEvent(id, display.ContainerRestartedEvent).WithPod(restart.PodName, restart.ContainerName)
+	return mt.handleContainerRestart(mt.TrackingJobs, id, spec, event, restart)
+}
+
+func (mt *multitracker) jobStatusReport(id ResourceID, spec MultitrackSpec, feed job.Feed, status job.JobStatus) error {
+	if debug() {
+		fmt.Printf("-- jobStatusReport %#v %#v\n", spec, status)
+	}
+
+	mt.JobsStatuses[id] = status
+	mt.handleResourceProgress(id)
+	mt.printer.ResourceStatus(mt.resourceEvent(id, display.StatusReportEvent).WithStatus(status))
+
+	return mt.handleResourcePodsStatuses(mt.TrackingJobs, id, spec, status.Pods)
+}
@@ -0,0 +1,107 @@
+package multitrack
+
+import (
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// manifestObject contains fields of kubernetes manifest needed to build MultitrackSpec
+type manifestObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name        string            `json:"name"`
+		Namespace   string            `json:"namespace"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+
+	// Items are set for List kind
+	Items []manifestObject `json:"items"`
+}
+
+// ReadSpecsFromManifests parses multi-document YAML or JSON stream of kubernetes manifests
+// and builds MultitrackSpecs for all Pods, Deployments, StatefulSets, DaemonSets and Jobs found.
+// Tracking options are read from kubedog.io/* annotations of the objects (see ApplySpecAnnotations).
+// Objects without namespace in manifest are placed into defaultNamespace.
+func ReadSpecsFromManifests(r io.Reader, defaultNamespace string) (MultitrackSpecs, error) {
+	specs := MultitrackSpecs{}
+
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+
+	for docIndex := 0; ; docIndex++ {
+		obj := manifestObject{}
+
+		err := decoder.Decode(&obj)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return MultitrackSpecs{}, fmt.Errorf("unable to parse manifest document %d: %s", docIndex, err)
+		}
+
+		if err := addManifestObjectSpec(&specs, obj, defaultNamespace); err != nil {
+			return MultitrackSpecs{}, fmt.Errorf("manifest document %d: %s", docIndex, err)
+		}
+	}
+
+	if err := ValidateSpecs(specs); err != nil {
+		return MultitrackSpecs{}, err
+	}
+
+	return specs, nil
+}
+
+func addManifestObjectSpec(specs *MultitrackSpecs, obj manifestObject, defaultNamespace string) error {
+	var specsList *[]MultitrackSpec
+	var kindShortName string
+
+	switch obj.Kind {
+	case "List", "PodList", "DeploymentList", "StatefulSetList", "DaemonSetList", "JobList":
+		for i, item := range obj.Items {
+			if err := addManifestObjectSpec(specs, item, defaultNamespace); err != nil {
+				return fmt.Errorf("items[%d]: %s", i, err)
+			}
+		}
+		return nil
+	case "Pod":
+		specsList = &specs.Pods
+		kindShortName = "po"
+	case "Deployment":
+		specsList = &specs.Deployments
+		kindShortName = "deploy"
+	case "StatefulSet":
+		specsList = &specs.StatefulSets
+		kindShortName = "sts"
+	case "DaemonSet":
+		specsList = &specs.DaemonSets
+		kindShortName = "ds"
+	case "Job":
+		specsList = &specs.Jobs
+		kindShortName = "job"
+	default:
+		// Empty documents and kinds which cannot be tracked are skipped
+		return nil
+	}
+
+	if obj.Metadata.Name == "" {
+		return fmt.Errorf("%s %s has no metadata.name", obj.APIVersion, obj.Kind)
+	}
+
+	spec := MultitrackSpec{
+		ResourceName: obj.Metadata.Name,
+		Namespace:    obj.Metadata.Namespace,
+	}
+	if spec.Namespace == "" {
+		spec.Namespace = defaultNamespace
+	}
+
+	if err := ApplySpecAnnotations(&spec, fmt.Sprintf("%s/%s", kindShortName, spec.ResourceName), obj.Metadata.Annotations); err != nil {
+		return err
+	}
+
+	*specsList = append(*specsList, spec)
+
+	return nil
+}
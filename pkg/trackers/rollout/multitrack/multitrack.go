@@ -1,8 +1,11 @@
 package multitrack
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -17,6 +20,7 @@ import (
 	"github.com/flant/kubedog/pkg/tracker/statefulset"
 
 	"k8s.io/client-go/kubernetes"
+	watchtools "k8s.io/client-go/tools/watch"
 )
 
 type FailMode string
@@ -43,9 +47,41 @@ type MultitrackSpecs struct {
 	Jobs         []MultitrackSpec
 }
 
+// Add appends spec of the resource of the kind: po, deploy, sts, ds or job, error is returned for other kinds
+func (specs *MultitrackSpecs) Add(kind string, spec MultitrackSpec) error {
+	group, err := specs.KindSpecs(kind)
+	if err != nil {
+		return err
+	}
+
+	*group = append(*group, spec)
+
+	return nil
+}
+
+// KindSpecs returns specs of resources of the kind: po, deploy, sts, ds or job, error is returned for other kinds
+func (specs *MultitrackSpecs) KindSpecs(kind string) (*[]MultitrackSpec, error) {
+	switch kind {
+	case "po":
+		return &specs.Pods, nil
+	case "deploy":
+		return &specs.Deployments, nil
+	case "sts":
+		return &specs.StatefulSets, nil
+	case "ds":
+		return &specs.DaemonSets, nil
+	case "job":
+		return &specs.Jobs, nil
+	default:
+		return nil, fmt.Errorf("unknown resource kind %q, expected one of: po, deploy, sts, ds, job", kind)
+	}
+}
+
 type MultitrackSpec struct {
 	ResourceName string
-	Namespace    string
+	// LabelSelector is an alternative to ResourceName: all resources matching the selector are tracked
+	LabelSelector string
+	Namespace     string
 
 	FailMode                FailMode
 	AllowFailuresCount      *int
@@ -56,6 +92,80 @@ type MultitrackSpec struct {
 	ShowLogsUntil                DeployCondition
 	SkipLogsForContainers        []string
 	ShowLogsOnlyForContainers    []string
+
+	// LogMinLevel hides JSON log lines with a lower level, lines without recognized level are always shown.
+	// All lines are shown when not set.
+	LogMinLevel display.LogLevel
+
+	// FailOnLogRegex counts a resource failure when a container log line matches it, even if the pod is ready.
+	// The failure is handled as pod errors are, according to AllowFailuresCount and FailMode.
+	FailOnLogRegex                *regexp.Regexp
+	FailOnLogRegexByContainerName map[string]*regexp.Regexp
+	// SucceedOnLogRegex marks the resource ready when a container log line matches it
+	SucceedOnLogRegex                *regexp.Regexp
+	SucceedOnLogRegexByContainerName map[string]*regexp.Regexp
+
+	// TimeoutSeconds limits the time of the resource tracking, 0 means no limit
+	TimeoutSeconds int
+	// NoProgressTimeoutSeconds limits the time without rollout progress of the resource, 0 means no limit
+	NoProgressTimeoutSeconds int
+	// UnschedulableTimeoutSeconds limits the time a pod of the resource stays unschedulable, 0 means no limit
+	UnschedulableTimeoutSeconds int
+
+	// MaxRestarts fails the resource according to FailMode when restarts of its pods containers
+	// since the start of tracking exceed it, no limit when not set
+	MaxRestarts *int
+
+	// DependsOn are resources in the same namespace in the form kind/name (e.g. job/migrate),
+	// resource is tracked only when all of them are ready
+	DependsOn []string
+	// TrackBeforeDependenciesReady starts tracking of the resource right away,
+	// only the resource ready verdict waits for DependsOn resources
+	TrackBeforeDependenciesReady bool
+}
+
+// ResourceID identifies tracked resource
+type ResourceID struct {
+	Namespace string
+	// Kind is a short kind name: po, deploy, sts, ds or job
+	Kind string
+	Name string
+}
+
+// String returns resource kind, name and namespace: deploy/mydeploy in ns/myns
+func (id ResourceID) String() string {
+	return fmt.Sprintf("%s in ns/%s", id.KindName(), id.Namespace)
+}
+
+// KindName returns resource kind and name: deploy/mydeploy
+func (id ResourceID) KindName() string {
+	return fmt.Sprintf("%s/%s", id.Kind, id.Name)
+}
+
+// ParseResourceKindName parses resource in the form kind/name and returns short kind name used in ResourceID.
+// Kind may be specified by short or full name: po, pod, deploy, deployment, sts, statefulset, ds, daemonset, job.
+func ParseResourceKindName(kindName string) (kind, name string, err error) {
+	parts := strings.SplitN(kindName, "/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", "", fmt.Errorf("bad resource %q, expected kind/name", kindName)
+	}
+
+	switch strings.ToLower(parts[0]) {
+	case "po", "pod", "pods":
+		kind = "po"
+	case "deploy", "deployment", "deployments":
+		kind = "deploy"
+	case "sts", "statefulset", "statefulsets":
+		kind = "sts"
+	case "ds", "daemonset", "daemonsets":
+		kind = "ds"
+	case "job", "jobs":
+		kind = "job"
+	default:
+		return "", "", fmt.Errorf("bad resource %q: unsupported kind %q, expected one of: po, deploy, sts, ds, job", kindName, parts[0])
+	}
+
+	return kind, parts[1], nil
 }
 
 type MultitrackOptions struct {
@@ -82,130 +192,92 @@ func setDefaultSpecValues(spec *MultitrackSpec) {
 	}
 }
 
+// Multitrack tracks resources described by specs until all of them are ready or failed.
+// Use MultitrackWithResult to get outcome of each tracked resource.
 func Multitrack(kube kubernetes.Interface, specs MultitrackSpecs, opts MultitrackOptions) error {
-	if len(specs.Pods)+len(specs.Deployments)+len(specs.StatefulSets)+len(specs.DaemonSets)+len(specs.Jobs) == 0 {
-		return nil
-	}
+	return MultitrackWithResult(kube, specs, opts).Err
+}
 
-	for i := range specs.Pods {
-		setDefaultSpecValues(&specs.Pods[i])
-	}
-	for i := range specs.Deployments {
-		setDefaultSpecValues(&specs.Deployments[i])
-	}
-	for i := range specs.StatefulSets {
-		setDefaultSpecValues(&specs.StatefulSets[i])
-	}
-	for i := range specs.DaemonSets {
-		setDefaultSpecValues(&specs.DaemonSets[i])
-	}
-	for i := range specs.Jobs {
-		setDefaultSpecValues(&specs.Jobs[i])
+// MultitrackWithResult tracks resources the same way as Multitrack and returns outcome of each tracked resource
+// along with the error returned by Multitrack.
+func MultitrackWithResult(kube kubernetes.Interface, specs MultitrackSpecs, opts MultitrackOptions) *MultitrackResult {
+	mt, err := runMultitrack(kube, specs, opts)
+
+	result := &MultitrackResult{Err: err}
+	if mt != nil {
+		result.Resources = mt.resourcesResults()
 	}
 
-	errorChan := make(chan error, 0)
-	doneChan := make(chan struct{}, 0)
+	return result
+}
 
-	mt := multitracker{
-		TrackingPods: make(map[string]*multitrackerResourceState),
-		PodsStatuses: make(map[string]pod.PodStatus),
+// runMultitrack returns multitracker when all trackers it has started are done
+func runMultitrack(kube kubernetes.Interface, specs MultitrackSpecs, opts MultitrackOptions) (*multitracker, error) {
+	if len(specs.Pods)+len(specs.Deployments)+len(specs.StatefulSets)+len(specs.DaemonSets)+len(specs.Jobs) == 0 {
+		return nil, nil
+	}
 
-		DeploymentsSpecs:        make(map[string]MultitrackSpec),
-		TrackingDeployments:     make(map[string]*multitrackerResourceState),
-		DeploymentsStatuses:     make(map[string]deployment.DeploymentStatus),
-		ShownDeploymentMessages: make(map[string]map[string]interface{}),
+	if err := ValidateSpecs(specs); err != nil {
+		return nil, fmt.Errorf("bad multitrack spec: %s", err)
+	}
 
-		TrackingStatefulSets: make(map[string]*multitrackerResourceState),
-		StatefulSetsStatuses: make(map[string]statefulset.StatefulSetStatus),
+	if hasLabelSelectorSpecs(specs) {
+		var err error
+		if specs, err = ExpandLabelSelectorSpecs(kube, specs); err != nil {
+			return nil, err
+		}
 
-		TrackingDaemonSets: make(map[string]*multitrackerResourceState),
-		DaemonSetsStatuses: make(map[string]daemonset.DaemonSetStatus),
+		if err := ValidateSpecs(specs); err != nil {
+			return nil, fmt.Errorf("bad multitrack spec: %s", err)
+		}
 
-		TrackingJobs: make(map[string]*multitrackerResourceState),
-		JobsStatuses: make(map[string]job.JobStatus),
+		if len(specs.Pods)+len(specs.Deployments)+len(specs.StatefulSets)+len(specs.DaemonSets)+len(specs.Jobs) == 0 {
+			return nil, fmt.Errorf("no resources match label selectors")
+		}
 	}
 
-	statusReportTicker := time.NewTicker(5 * time.Second)
-	defer statusReportTicker.Stop()
+	mt := newMultitracker(kube, specs, opts)
 
-	var wg sync.WaitGroup
+	parentContext := opts.ParentContext
+	if parentContext == nil {
+		parentContext = context.Background()
+	}
+	// Timeout is the overall cap of tracking including resources started later because of dependencies
+	ctx, cancel := watchtools.ContextWithOptionalTimeout(parentContext, opts.Timeout)
 
-	for _, spec := range specs.Pods {
-		mt.TrackingPods[spec.ResourceName] = &multitrackerResourceState{}
+	mt.trackersContext = ctx
 
-		wg.Add(1)
-		go func(spec MultitrackSpec) {
-			if err := mt.TrackPod(kube, spec, opts); err != nil {
-				errorChan <- fmt.Errorf("po/%s track failed: %s", spec.ResourceName, err)
-			}
-			wg.Done()
-		}(spec)
-	}
-	for _, spec := range specs.Deployments {
-		mt.DeploymentsSpecs[spec.ResourceName] = spec
-		mt.TrackingDeployments[spec.ResourceName] = &multitrackerResourceState{}
+	statusReportTicker := time.NewTicker(5 * time.Second)
+	defer statusReportTicker.Stop()
 
-		wg.Add(1)
-		go func(spec MultitrackSpec) {
-			if err := mt.TrackDeployment(kube, spec, opts); err != nil {
-				errorChan <- fmt.Errorf("deploy/%s track failed: %s", spec.ResourceName, err)
-			}
-			wg.Done()
-		}(spec)
-	}
-	for _, spec := range specs.StatefulSets {
-		mt.TrackingStatefulSets[spec.ResourceName] = &multitrackerResourceState{}
+	timeoutsTicker := time.NewTicker(time.Second)
+	defer timeoutsTicker.Stop()
 
-		wg.Add(1)
-		go func(spec MultitrackSpec) {
-			if err := mt.TrackStatefulSet(kube, spec, opts); err != nil {
-				errorChan <- fmt.Errorf("sts/%s track failed: %s", spec.ResourceName, err)
-			}
-			wg.Done()
-		}(spec)
-	}
-	for _, spec := range specs.DaemonSets {
-		mt.TrackingDaemonSets[spec.ResourceName] = &multitrackerResourceState{}
+	// Handlers of started trackers wait until all trackers are registered
+	mt.handlerMux.Lock()
 
-		wg.Add(1)
-		go func(spec MultitrackSpec) {
-			if err := mt.TrackDaemonSet(kube, spec, opts); err != nil {
-				errorChan <- fmt.Errorf("ds/%s track failed: %s", spec.ResourceName, err)
-			}
-			wg.Done()
-		}(spec)
+	for _, id := range mt.resourcesOrder {
+		if mt.resourcesSpecs(id.Kind)[id].TrackBeforeDependenciesReady || mt.areDependenciesReady(id) {
+			mt.runTracker(id)
+		} else {
+			mt.waitingResources[id] = true
+			msg := fmt.Sprintf("waiting for %s", strings.Join(mt.notReadyDependencies(id), ", "))
+			mt.printer.Message(mt.resourceEvent(id, display.MessageEvent).WithMessage(msg), fmt.Sprintf("# %s %s", mt.resourceName(id), msg))
+		}
 	}
-	for _, spec := range specs.Jobs {
-		mt.TrackingJobs[spec.ResourceName] = &multitrackerResourceState{}
 
-		wg.Add(1)
-		go func(spec MultitrackSpec) {
-			if err := mt.TrackJob(kube, spec, opts); err != nil {
-				errorChan <- fmt.Errorf("job/%s track failed: %s", spec.ResourceName, err)
-			}
-			wg.Done()
-		}(spec)
-	}
+	mt.handlerMux.Unlock()
 
+	trackersDone := make(chan struct{})
 	go func() {
-		wg.Wait()
-
-		err := func() error {
-			mt.handlerMux.Lock()
-			defer mt.handlerMux.Unlock()
-			return mt.PrintStatusReport()
-		}()
-
-		if err != nil {
-			errorChan <- err
-			return
-		}
+		mt.trackersWaitGroup.Wait()
+		close(trackersDone)
+	}()
 
-		if mt.hasFailedTrackingResources() {
-			errorChan <- mt.formatFailedTrackingResourcesError()
-		} else {
-			doneChan <- struct{}{}
-		}
+	defer func() {
+		// Stop all trackers and wait for them to exit
+		cancel()
+		<-trackersDone
 	}()
 
 	for {
@@ -223,36 +295,170 @@ func Multitrack(kube kubernetes.Interface, specs MultitrackSpecs, opts Multitrac
 			}()
 
 			if err != nil {
-				return err
+				return mt, err
 			}
 
-		case <-doneChan:
-			return nil
+		case <-timeoutsTicker.C:
+			mt.handlerMux.Lock()
+			mt.checkResourcesTimeouts()
+			mt.handlerMux.Unlock()
+
+		case <-trackersDone:
+			select {
+			case err := <-mt.errorChan:
+				return mt, err
+			default:
+			}
+
+			mt.handlerMux.Lock()
+			defer mt.handlerMux.Unlock()
+
+			mt.failWaitingResources()
+
+			if err := mt.PrintStatusReport(); err != nil {
+				return mt, err
+			}
+
+			if mt.hasFailedTrackingResources() {
+				return mt, mt.formatFailedTrackingResourcesError()
+			}
+			return mt, nil
 
-		case err := <-errorChan:
-			return err
+		case err := <-mt.errorChan:
+			return mt, err
 		}
 	}
 }
 
+// newMultitracker makes multitracker of the resources of specs, trackers are not started
+func newMultitracker(kube kubernetes.Interface, specs MultitrackSpecs, opts MultitrackOptions) *multitracker {
+	mt := &multitracker{
+		PodsSpecs:    make(map[ResourceID]MultitrackSpec),
+		TrackingPods: make(map[ResourceID]*multitrackerResourceState),
+		PodsStatuses: make(map[ResourceID]pod.PodStatus),
+
+		DeploymentsSpecs:        make(map[ResourceID]MultitrackSpec),
+		TrackingDeployments:     make(map[ResourceID]*multitrackerResourceState),
+		DeploymentsStatuses:     make(map[ResourceID]deployment.DeploymentStatus),
+		ShownDeploymentMessages: make(map[ResourceID]map[string]interface{}),
+
+		StatefulSetsSpecs:    make(map[ResourceID]MultitrackSpec),
+		TrackingStatefulSets: make(map[ResourceID]*multitrackerResourceState),
+		StatefulSetsStatuses: make(map[ResourceID]statefulset.StatefulSetStatus),
+
+		DaemonSetsSpecs:    make(map[ResourceID]MultitrackSpec),
+		TrackingDaemonSets: make(map[ResourceID]*multitrackerResourceState),
+		DaemonSetsStatuses: make(map[ResourceID]daemonset.DaemonSetStatus),
+
+		JobsSpecs:    make(map[ResourceID]MultitrackSpec),
+		TrackingJobs: make(map[ResourceID]*multitrackerResourceState),
+		JobsStatuses: make(map[ResourceID]job.JobStatus),
+
+		LingeringResources: make(map[ResourceID]bool),
+		passedSpecs:        make(map[ResourceID]MultitrackSpec),
+
+		runningTrackers:         make(map[ResourceID]bool),
+		resourcesContextCancels: make(map[ResourceID]context.CancelFunc),
+
+		startedAt: time.Now(),
+		results:   make(map[ResourceID]*ResourceResult),
+
+		kube:                  kube,
+		resourcesDependencies: make(map[ResourceID][]ResourceID),
+		waitingResources:      make(map[ResourceID]bool),
+		stoppedTrackers:       make(map[ResourceID]bool),
+		resourcesTimeouts:     make(map[ResourceID]*resourceTimeouts),
+	}
+
+	mt.isMultiNamespace = isMultiNamespace(specs)
+	mt.trackersOptions = opts
+	mt.printer = opts.GetPrinter()
+	mt.errorChan = make(chan error, len(specs.Pods)+len(specs.Deployments)+len(specs.StatefulSets)+len(specs.DaemonSets)+len(specs.Jobs))
+
+	for _, group := range []struct {
+		Kind  string
+		Specs []MultitrackSpec
+	}{
+		{"po", specs.Pods},
+		{"deploy", specs.Deployments},
+		{"sts", specs.StatefulSets},
+		{"ds", specs.DaemonSets},
+		{"job", specs.Jobs},
+	} {
+		for _, spec := range group.Specs {
+			id := ResourceID{Namespace: spec.Namespace, Kind: group.Kind, Name: spec.ResourceName}
+			mt.passedSpecs[id] = spec
+			setDefaultSpecValues(&spec)
+			mt.resourcesSpecs(id.Kind)[id] = spec
+			mt.resourcesStates(id.Kind)[id] = &multitrackerResourceState{}
+			mt.resourcesDependencies[id] = specDependencies(spec)
+
+			mt.results[id] = &ResourceResult{ID: id}
+			mt.resourcesOrder = append(mt.resourcesOrder, id)
+		}
+	}
+
+	return mt
+}
+
 type multitracker struct {
-	DeploymentsSpecs map[string]MultitrackSpec
+	PodsSpecs         map[ResourceID]MultitrackSpec
+	DeploymentsSpecs  map[ResourceID]MultitrackSpec
+	StatefulSetsSpecs map[ResourceID]MultitrackSpec
+	DaemonSetsSpecs   map[ResourceID]MultitrackSpec
+	JobsSpecs         map[ResourceID]MultitrackSpec
+
+	TrackingPods map[ResourceID]*multitrackerResourceState
+	PodsStatuses map[ResourceID]pod.PodStatus
 
-	TrackingPods map[string]*multitrackerResourceState
-	PodsStatuses map[string]pod.PodStatus
+	TrackingDeployments     map[ResourceID]*multitrackerResourceState
+	DeploymentsStatuses     map[ResourceID]deployment.DeploymentStatus
+	ShownDeploymentMessages map[ResourceID]map[string]interface{}
 
-	TrackingDeployments     map[string]*multitrackerResourceState
-	DeploymentsStatuses     map[string]deployment.DeploymentStatus
-	ShownDeploymentMessages map[string]map[string]interface{}
+	TrackingStatefulSets map[ResourceID]*multitrackerResourceState
+	StatefulSetsStatuses map[ResourceID]statefulset.StatefulSetStatus
 
-	TrackingStatefulSets map[string]*multitrackerResourceState
-	StatefulSetsStatuses map[string]statefulset.StatefulSetStatus
+	TrackingDaemonSets map[ResourceID]*multitrackerResourceState
+	DaemonSetsStatuses map[ResourceID]daemonset.DaemonSetStatus
 
-	TrackingDaemonSets map[string]*multitrackerResourceState
-	DaemonSetsStatuses map[string]daemonset.DaemonSetStatus
+	TrackingJobs map[ResourceID]*multitrackerResourceState
+	JobsStatuses map[ResourceID]job.JobStatus
 
-	TrackingJobs map[string]*multitrackerResourceState
-	JobsStatuses map[string]job.JobStatus
+	// LingeringResources are ready resources with ShowLogsUntil=EndOfDeploy,
+	// which trackers are kept running only to show logs until the end of deploy process
+	LingeringResources map[ResourceID]bool
+
+	// passedSpecs are specs without default values, annotations of the tracked objects are applied to them
+	passedSpecs map[ResourceID]MultitrackSpec
+
+	// isMultiNamespace is set when tracked resources are in different namespaces,
+	// namespace is shown in resource names then
+	isMultiNamespace bool
+
+	// runningTrackers are resources which tracker goroutines are not done yet
+	runningTrackers         map[ResourceID]bool
+	resourcesContextCancels map[ResourceID]context.CancelFunc
+	isDeployEnded           bool
+
+	startedAt time.Time
+	// results are outcomes of tracked resources, resourcesOrder is the order of specs
+	results        map[ResourceID]*ResourceResult
+	resourcesOrder []ResourceID
+
+	kube              kubernetes.Interface
+	trackersContext   context.Context
+	trackersOptions   MultitrackOptions
+	printer           display.Printer
+	trackersWaitGroup sync.WaitGroup
+	errorChan         chan error
+
+	resourcesDependencies map[ResourceID][]ResourceID
+	// waitingResources are resources which tracking has not been started until dependencies are ready
+	waitingResources map[ResourceID]bool
+	// stoppedTrackers are trackers cancelled by multitracker, their errors are not relevant
+	stoppedTrackers map[ResourceID]bool
+
+	resourcesTimeouts map[ResourceID]*resourceTimeouts
 
 	handlerMux sync.Mutex
 }
@@ -261,10 +467,120 @@ type multitrackerResourceState struct {
 	IsFailed          bool
 	LastFailureReason string
 	FailuresCount     int
+
+	// FailuresWindowStartedAt is the time of the first failure since the last recovery of the resource
+	FailuresWindowStartedAt time.Time
+	// FailedPods are the pods which failed since the last recovery of the resource
+	FailedPods []string
+
+	// IsReadyPendingDependencies is set when the resource with TrackBeforeDependenciesReady
+	// has become ready before its dependencies
+	IsReadyPendingDependencies bool
+
+	// SuppressedLogs are the logs of ready pods not shown because of ShowLogsUntil=PodIsReady,
+	// these logs are shown when the resource fails
+	SuppressedLogs          []display.LogChunk
+	SuppressedLogLinesCount int
+
+	// LastContainerTermination is the last crashed container instance of the resource pods,
+	// its details are added to the failure reason
+	LastContainerTermination *pod.PodContainerTermination
+
+	// ContainersRestarts are the restarts of the resource pods containers since the start of tracking by pod/container
+	ContainersRestarts map[string]int32
+}
+
+func (state *multitrackerResourceState) restartsCount() int {
+	count := 0
+	for _, restarts := range state.ContainersRestarts {
+		count += int(restarts)
+	}
+	return count
+}
+
+// failureReason returns LastFailureReason with the details of the last crashed container instance
+func (state *multitrackerResourceState) failureReason() string {
+	termination := state.LastContainerTermination
+	if termination == nil || strings.Contains(state.LastFailureReason, termination.Summary()) {
+		return state.LastFailureReason
+	}
+	return fmt.Sprintf("%s (last container termination: po/%s container/%s: %s)", state.LastFailureReason, termination.PodName, termination.ContainerName, termination.Summary())
+}
+
+// applyObjectAnnotations sets the resource spec from the spec passed to multitracker and kubedog.io/* annotations
+// of the tracked object, values set in the passed spec win over annotations
+func (mt *multitracker) applyObjectAnnotations(id ResourceID, spec *MultitrackSpec, annotations map[string]string) error {
+	newSpec := mt.passedSpecs[id]
+	if err := ApplySpecAnnotations(&newSpec, id.KindName(), annotations); err != nil {
+		return err
+	}
+	setDefaultSpecValues(&newSpec)
+
+	*spec = newSpec
+	return nil
+}
+
+// maxSuppressedLogLines limits the number of the last suppressed log lines kept for the resource
+const maxSuppressedLogLines = 1000
+
+func (state *multitrackerResourceState) suppressLogLines(event display.Event, header string, logLines []display.LogLine) {
+	state.SuppressedLogs = append(state.SuppressedLogs, display.LogChunk{Event: event, Header: header, LogLines: logLines})
+	state.SuppressedLogLinesCount += len(logLines)
+
+	for state.SuppressedLogLinesCount > maxSuppressedLogLines {
+		oldestChunk := &state.SuppressedLogs[0]
+		excessLinesCount := state.SuppressedLogLinesCount - maxSuppressedLogLines
+
+		if excessLinesCount < len(oldestChunk.LogLines) {
+			oldestChunk.LogLines = oldestChunk.LogLines[excessLinesCount:]
+			state.SuppressedLogLinesCount -= excessLinesCount
+		} else {
+			state.SuppressedLogLinesCount -= len(oldestChunk.LogLines)
+			state.SuppressedLogs = state.SuppressedLogs[1:]
+		}
+	}
+}
+
+func (state *multitrackerResourceState) showSuppressedLogs(printer display.Printer) {
+	for _, chunk := range display.OrderLogChunks(state.SuppressedLogs) {
+		printer.LogLines(chunk.Event, chunk.Header, chunk.LogLines)
+	}
+	state.SuppressedLogs = nil
+	state.SuppressedLogLinesCount = 0
+}
+
+func (state *multitrackerResourceState) isFailuresBudgetExceeded(spec MultitrackSpec) bool {
+	if state.FailuresCount <= *spec.AllowFailuresCount {
+		return false
+	}
+
+	threshold := time.Duration(*spec.FailureThresholdSeconds) * time.Second
+	return time.Since(state.FailuresWindowStartedAt) >= threshold
+}
+
+func (state *multitrackerResourceState) resetFailuresBudget() {
+	state.FailuresCount = 0
+	state.FailuresWindowStartedAt = time.Time{}
+	state.FailedPods = nil
+}
+
+func (state *multitrackerResourceState) failuresBudgetMessage(spec MultitrackSpec) string {
+	msg := fmt.Sprintf("%d of %d allowed failures", state.FailuresCount, *spec.AllowFailuresCount)
+
+	if *spec.FailureThresholdSeconds > 0 {
+		threshold := time.Duration(*spec.FailureThresholdSeconds) * time.Second
+		left := threshold - time.Since(state.FailuresWindowStartedAt)
+		if left < 0 {
+			left = 0
+		}
+		msg += fmt.Sprintf(", %s of %s failure threshold left", left.Truncate(time.Second), threshold)
+	}
+
+	return msg
 }
 
 func (mt *multitracker) isTrackingAnyNonFailedResource() bool {
-	for _, states := range []map[string]*multitrackerResourceState{
+	for _, states := range []map[ResourceID]*multitrackerResourceState{
 		mt.TrackingPods,
 		mt.TrackingDeployments,
 		mt.TrackingStatefulSets,
@@ -282,7 +598,7 @@ func (mt *multitracker) isTrackingAnyNonFailedResource() bool {
 }
 
 func (mt *multitracker) hasFailedTrackingResources() bool {
-	for _, states := range []map[string]*multitrackerResourceState{
+	for _, states := range []map[ResourceID]*multitrackerResourceState{
 		mt.TrackingPods,
 		mt.TrackingDeployments,
 		mt.TrackingStatefulSets,
@@ -301,118 +617,401 @@ func (mt *multitracker) hasFailedTrackingResources() bool {
 func (mt *multitracker) formatFailedTrackingResourcesError() error {
 	msgParts := []string{}
 
-	for name, state := range mt.TrackingPods {
+	for id, state := range mt.TrackingPods {
 		if !state.IsFailed {
 			continue
 		}
-		msgParts = append(msgParts, fmt.Sprintf("po/%s failed: %s", name, state.LastFailureReason))
+		msgParts = append(msgParts, fmt.Sprintf("%s failed: %s", mt.resourceName(id), state.failureReason()))
 	}
-	for name, state := range mt.TrackingDeployments {
+	for id, state := range mt.TrackingDeployments {
 		if !state.IsFailed {
 			continue
 		}
-		msgParts = append(msgParts, fmt.Sprintf("deploy/%s failed: %s", name, state.LastFailureReason))
+		msgParts = append(msgParts, fmt.Sprintf("%s failed: %s", mt.resourceName(id), state.failureReason()))
 	}
-	for name, state := range mt.TrackingStatefulSets {
+	for id, state := range mt.TrackingStatefulSets {
 		if !state.IsFailed {
 			continue
 		}
-		msgParts = append(msgParts, fmt.Sprintf("sts/%s failed: %s", name, state.LastFailureReason))
+		msgParts = append(msgParts, fmt.Sprintf("%s failed: %s", mt.resourceName(id), state.failureReason()))
 	}
-	for name, state := range mt.TrackingDaemonSets {
+	for id, state := range mt.TrackingDaemonSets {
 		if !state.IsFailed {
 			continue
 		}
-		msgParts = append(msgParts, fmt.Sprintf("ds/%s failed: %s", name, state.LastFailureReason))
+		msgParts = append(msgParts, fmt.Sprintf("%s failed: %s", mt.resourceName(id), state.failureReason()))
 	}
-	for name, state := range mt.TrackingJobs {
+	for id, state := range mt.TrackingJobs {
 		if !state.IsFailed {
 			continue
 		}
-		msgParts = append(msgParts, fmt.Sprintf("job/%s failed: %s", name, state.LastFailureReason))
+		msgParts = append(msgParts, fmt.Sprintf("%s failed: %s", mt.resourceName(id), state.failureReason()))
 	}
 
 	return fmt.Errorf("%s", strings.Join(msgParts, "\n"))
 }
 
-func (mt *multitracker) handleResourceReadyCondition(resourcesStates map[string]*multitrackerResourceState, spec MultitrackSpec) error {
-	delete(resourcesStates, spec.ResourceName)
-	return tracker.StopTrack
+// handleResourceReadyCondition stops tracking of the ready resource.
+// Resource with ShowLogsUntil=EndOfDeploy keeps its tracker running to show logs until the end of deploy process.
+func (mt *multitracker) handleResourceReadyCondition(resourcesStates map[ResourceID]*multitrackerResourceState, id ResourceID, spec MultitrackSpec) error {
+	if mt.isDeployEnded {
+		return tracker.StopTrack
+	}
+
+	if !mt.areDependenciesReady(id) {
+		if state := resourcesStates[id]; state != nil && !state.IsReadyPendingDependencies {
+			state.IsReadyPendingDependencies = true
+			msg := fmt.Sprintf("is ready, waiting for %s", strings.Join(mt.notReadyDependencies(id), ", "))
+			mt.printer.Message(mt.resourceEvent(id, display.MessageEvent).WithMessage(msg), fmt.Sprintf("# %s %s", mt.resourceName(id), msg))
+		}
+		return nil
+	}
+
+	if mt.finishReadyResource(resourcesStates, id, spec) {
+		return tracker.StopTrack
+	}
+	return nil
+}
+
+// finishReadyResource marks resource as ready and returns true when its tracker should be stopped
+func (mt *multitracker) finishReadyResource(resourcesStates map[ResourceID]*multitrackerResourceState, id ResourceID, spec MultitrackSpec) bool {
+	delete(resourcesStates, id)
+	mt.setResourceReady(id)
+	mt.handleDependencyReady(id)
+
+	if spec.ShowLogsUntil == EndOfDeploy {
+		mt.LingeringResources[id] = true
+		mt.checkEndOfDeploy()
+		return false
+	}
+
+	return true
+}
+
+func (mt *multitracker) isResourceTracked(resourcesStates map[ResourceID]*multitrackerResourceState, id ResourceID) bool {
+	_, hasKey := resourcesStates[id]
+	return hasKey
+}
+
+// startTracker registers running tracker of the resource and returns tracker options with the resource context,
+// which is cancelled when the tracker is no longer needed
+func (mt *multitracker) startTracker(ctx context.Context, id ResourceID, opts MultitrackOptions) MultitrackOptions {
+	resourceContext, cancel := context.WithCancel(ctx)
+
+	mt.runningTrackers[id] = true
+	mt.resourcesContextCancels[id] = cancel
+
+	now := time.Now()
+	mt.resourcesTimeouts[id] = &resourceTimeouts{TrackingStartedAt: now, LastProgressAt: now}
+
+	opts.ParentContext = resourceContext
+	return opts
+}
+
+// runTracker starts tracker goroutine of the resource
+func (mt *multitracker) runTracker(id ResourceID) {
+	spec := mt.resourcesSpecs(id.Kind)[id]
+	opts := mt.startTracker(mt.trackersContext, id, mt.trackersOptions)
+
+	var track func(kube kubernetes.Interface, spec MultitrackSpec, opts MultitrackOptions) error
+	switch id.Kind {
+	case "po":
+		track = mt.TrackPod
+	case "deploy":
+		track = mt.TrackDeployment
+	case "sts":
+		track = mt.TrackStatefulSet
+	case "ds":
+		track = mt.TrackDaemonSet
+	case "job":
+		track = mt.TrackJob
+	default:
+		panic(fmt.Sprintf("unknown resource kind %s", id.Kind))
+	}
+
+	mt.trackersWaitGroup.Add(1)
+	go func() {
+		err := track(mt.kube, spec, opts)
+		if err := mt.handleTrackerDone(id, err); err != nil {
+			mt.errorChan <- fmt.Errorf("%s track failed: %s", mt.resourceName(id), err)
+		}
+		mt.trackersWaitGroup.Done()
+	}()
+}
+
+// stopTracker cancels tracker of the resource from outside of the tracker callbacks
+func (mt *multitracker) stopTracker(id ResourceID) {
+	mt.stoppedTrackers[id] = true
+	mt.resourcesContextCancels[id]()
+}
+
+// handleTrackerDone is called when tracker goroutine of the resource exits with the tracker error
+func (mt *multitracker) handleTrackerDone(id ResourceID, err error) error {
+	mt.handlerMux.Lock()
+	defer mt.handlerMux.Unlock()
+
+	delete(mt.runningTrackers, id)
+	delete(mt.LingeringResources, id)
+	mt.resourcesContextCancels[id]()
+
+	// Remaining trackers are stopped at the end of deploy process, their resources are already ready or failed
+	if mt.isDeployEnded || mt.stoppedTrackers[id] {
+		err = nil
+	}
+
+	if err != nil && err != tracker.ErrTrackInterrupted {
+		mt.setResourceTrackerFailed(id, err)
+	}
+
+	mt.checkEndOfDeploy()
+
+	return err
+}
+
+// checkEndOfDeploy stops trackers of lingering resources and of failed resources with HopeUntilEndOfDeployProcess
+// when all other trackers are done. Failed resources get the last evaluation with the last known status before that.
+func (mt *multitracker) checkEndOfDeploy() {
+	if mt.isDeployEnded || len(mt.runningTrackers) == 0 || mt.hasActiveTrackers() {
+		return
+	}
+
+	for id := range mt.runningTrackers {
+		if mt.isHopingResource(id) {
+			mt.evaluateHopingResource(id)
+		}
+	}
+
+	if mt.hasActiveTrackers() {
+		return
+	}
+
+	mt.isDeployEnded = true
+
+	// Dependencies failed with HopeUntilEndOfDeployProcess will not become ready
+	for _, id := range mt.resourcesOrder {
+		if mt.isWaitingForDependencies(id) {
+			mt.failDependentResource(id, fmt.Sprintf("dependencies are not ready at the end of deploy process: %s", strings.Join(mt.notReadyDependencies(id), ", ")))
+		}
+	}
+
+	for id := range mt.runningTrackers {
+		mt.resourcesContextCancels[id]()
+	}
+}
+
+// hasActiveTrackers returns true when some of running trackers track resources which are neither ready nor failed
+// nor waiting for dependencies
+func (mt *multitracker) hasActiveTrackers() bool {
+	for id := range mt.runningTrackers {
+		if !mt.LingeringResources[id] && !mt.isHopingResource(id) && !mt.isWaitingForDependencies(id) {
+			return true
+		}
+	}
+	return false
+}
+
+func (mt *multitracker) isHopingResource(id ResourceID) bool {
+	state, hasKey := mt.resourcesStates(id.Kind)[id]
+	if !hasKey {
+		return false
+	}
+	return state.IsFailed && mt.resourcesSpecs(id.Kind)[id].FailMode == HopeUntilEndOfDeployProcess
+}
+
+// evaluateHopingResource checks whether failed resource has recovered according to the last known status
+func (mt *multitracker) evaluateHopingResource(id ResourceID) {
+	states := mt.resourcesStates(id.Kind)
+	spec := mt.resourcesSpecs(id.Kind)[id]
+
+	if !mt.areDependenciesReady(id) {
+		return
+	}
+
+	var podsStatuses map[string]pod.PodStatus
+
+	switch id.Kind {
+	case "po":
+		status, hasKey := mt.PodsStatuses[id]
+		if !hasKey {
+			// No known status to evaluate
+			return
+		}
+		if isPodReady(status) {
+			mt.markLingeringResourceReady(states, id)
+			return
+		}
+		podsStatuses = map[string]pod.PodStatus{id.Name: status}
+	case "deploy":
+		status := mt.DeploymentsStatuses[id]
+		if status.ReadyStatus.IsReady {
+			mt.markLingeringResourceReady(states, id)
+			return
+		}
+		podsStatuses = status.Pods
+	case "sts":
+		podsStatuses = mt.StatefulSetsStatuses[id].Pods
+	case "ds":
+		podsStatuses = mt.DaemonSetsStatuses[id].Pods
+	case "job":
+		status := mt.JobsStatuses[id]
+		if status.Succeeded > 0 && status.Active == 0 {
+			mt.markLingeringResourceReady(states, id)
+			return
+		}
+		podsStatuses = status.Pods
+	}
+
+	mt.handleResourcePodsStatuses(states, id, spec, podsStatuses)
+}
+
+// markLingeringResourceReady marks resource as ready, while its tracker is still running
+func (mt *multitracker) markLingeringResourceReady(resourcesStates map[ResourceID]*multitrackerResourceState, id ResourceID) {
+	mt.printer.Message(mt.resourceEvent(id, display.ReadyEvent), fmt.Sprintf("# %s appears to be READY", mt.resourceName(id)))
+	delete(resourcesStates, id)
+	mt.setResourceReady(id)
+	mt.LingeringResources[id] = true
+	mt.handleDependencyReady(id)
+}
+
+func (mt *multitracker) resourcesStates(kind string) map[ResourceID]*multitrackerResourceState {
+	switch kind {
+	case "po":
+		return mt.TrackingPods
+	case "deploy":
+		return mt.TrackingDeployments
+	case "sts":
+		return mt.TrackingStatefulSets
+	case "ds":
+		return mt.TrackingDaemonSets
+	case "job":
+		return mt.TrackingJobs
+	default:
+		panic(fmt.Sprintf("unknown resource kind %s", kind))
+	}
+}
+
+func (mt *multitracker) resourcesSpecs(kind string) map[ResourceID]MultitrackSpec {
+	switch kind {
+	case "po":
+		return mt.PodsSpecs
+	case "deploy":
+		return mt.DeploymentsSpecs
+	case "sts":
+		return mt.StatefulSetsSpecs
+	case "ds":
+		return mt.DaemonSetsSpecs
+	case "job":
+		return mt.JobsSpecs
+	default:
+		panic(fmt.Sprintf("unknown resource kind %s", kind))
+	}
+}
+
+// resourceName returns resource name for output, namespace is shown only when tracked resources are in different namespaces
+func (mt *multitracker) resourceName(id ResourceID) string {
+	if mt.isMultiNamespace {
+		return id.String()
+	}
+	return id.KindName()
+}
+
+// resourceEvent returns json output event of the resource
+func (mt *multitracker) resourceEvent(id ResourceID, eventType display.EventType) display.Event {
+	return display.Event{Type: eventType, Kind: id.Kind, Namespace: id.Namespace, Name: id.Name}
+}
+
+func isMultiNamespace(specs MultitrackSpecs) bool {
+	namespaces := make(map[string]bool)
+	for _, group := range [][]MultitrackSpec{specs.Pods, specs.Deployments, specs.StatefulSets, specs.DaemonSets, specs.Jobs} {
+		for _, spec := range group {
+			namespaces[spec.Namespace] = true
+		}
+	}
+	return len(namespaces) > 1
 }
 
 func (mt *multitracker) PrintStatusReport() error {
+	report := &strings.Builder{}
 	caption := color.New(color.Bold).Sprint("Status Report")
 
-	display.OutF("\n┌ %s\n", caption)
+	fmt.Fprintf(report, "\n┌ %s\n", caption)
 
-	for name, status := range mt.PodsStatuses {
-		display.OutF("├ po/%s\n", name)
+	for id, status := range mt.PodsStatuses {
+		fmt.Fprintf(report, "├ %s\n", mt.resourceName(id))
+		mt.printResourceFailuresBudget(report, mt.TrackingPods, id, mt.PodsSpecs[id])
+		mt.printResourceRestarts(report, mt.TrackingPods, id, mt.PodsSpecs[id])
+		mt.printUnschedulablePods(report, map[string]pod.PodStatus{id.Name: status})
+		mt.printProbeFailures(report, map[string]pod.PodStatus{id.Name: status})
 
 		if status.Phase != "" {
-			display.OutF("│   Phase:%s\n", status.Phase)
+			fmt.Fprintf(report, "│   Phase:%s\n", status.Phase)
 		}
 
 		if len(status.Conditions) > 0 {
-			display.OutF("│   Conditions:\n")
+			fmt.Fprintf(report, "│   Conditions:\n")
 		}
 		for _, cond := range status.Conditions {
-			display.OutF("│   - %s %s:%s", cond.LastTransitionTime, cond.Type, cond.Status)
+			fmt.Fprintf(report, "│   - %s %s:%s", cond.LastTransitionTime, cond.Type, cond.Status)
 			if cond.Reason != "" {
-				display.OutF(" %s", cond.Reason)
+				fmt.Fprintf(report, " %s", cond.Reason)
 			}
 			if cond.Message != "" {
-				display.OutF(" %s", cond.Message)
+				fmt.Fprintf(report, " %s", cond.Message)
 			}
-			display.OutF("\n")
+			fmt.Fprintf(report, "\n")
 		}
 	}
 
-	for name, status := range mt.DeploymentsStatuses {
-		spec := mt.DeploymentsSpecs[name]
+	for id, status := range mt.DeploymentsStatuses {
+		spec := mt.DeploymentsSpecs[id]
 
-		if _, hasKey := mt.ShownDeploymentMessages[name]; !hasKey {
-			mt.ShownDeploymentMessages[name] = make(map[string]interface{})
+		if _, hasKey := mt.ShownDeploymentMessages[id]; !hasKey {
+			mt.ShownDeploymentMessages[id] = make(map[string]interface{})
 		}
 
 		var resource string
 
 		if spec.FailMode == FailWholeDeployProcessImmediately {
 			if status.ReadyStatus.IsReady {
-				resource = color.New(color.FgGreen).Sprintf("deploy/%s", name)
+				resource = color.New(color.FgGreen).Sprint(mt.resourceName(id))
 			} else if status.IsFailed {
-				resource = color.New(color.FgRed).Sprintf("deploy/%s", name)
+				resource = color.New(color.FgRed).Sprint(mt.resourceName(id))
 			} else {
-				resource = color.New(color.FgYellow).Sprintf("deploy/%s", name)
+				resource = color.New(color.FgYellow).Sprint(mt.resourceName(id))
 			}
 		} else if spec.FailMode == IgnoreAndContinueDeployProcess {
 			if status.ReadyStatus.IsReady {
-				resource = color.New(color.FgGreen).Sprintf("deploy/%s", name)
+				resource = color.New(color.FgGreen).Sprint(mt.resourceName(id))
 			} else {
-				resource = fmt.Sprintf("deploy/%s", name)
+				resource = mt.resourceName(id)
 			}
 		} else if spec.FailMode == HopeUntilEndOfDeployProcess {
 			if status.ReadyStatus.IsReady {
-				resource = color.New(color.FgGreen).Sprintf("deploy/%s", name)
+				resource = color.New(color.FgGreen).Sprint(mt.resourceName(id))
 			} else {
-				resource = color.New(color.FgYellow).Sprintf("deploy/%s", name)
+				resource = color.New(color.FgYellow).Sprint(mt.resourceName(id))
 			}
 		}
 
-		display.OutF("├ %s\n", resource)
+		fmt.Fprintf(report, "├ %s\n", resource)
+		mt.printResourceFailuresBudget(report, mt.TrackingDeployments, id, spec)
+		mt.printResourceRestarts(report, mt.TrackingDeployments, id, spec)
+		mt.printUnschedulablePods(report, status.Pods)
+		mt.printProbeFailures(report, status.Pods)
 		if status.IsFailed {
-			display.OutF("│   %s\n", color.New(color.FgRed).Sprintf("❌ %s", status.FailedReason))
+			fmt.Fprintf(report, "│   %s\n", color.New(color.FgRed).Sprintf("❌ %s", status.FailedReason))
 
 			for podName, podStatus := range status.Pods {
 				if podStatus.IsFailed {
-					display.OutF("│   %s\n", color.New(color.FgRed).Sprintf("❌ pod/%s %s", podName, podStatus.FailedReason))
+					fmt.Fprintf(report, "│   %s\n", color.New(color.FgRed).Sprintf("❌ pod/%s %s", podName, podStatus.FailedReason))
 				}
 			}
 		} else {
 			for _, cond := range status.ReadyStatus.ProgressingConditions {
 				if cond.IsSatisfied {
-					if _, hasKey := mt.ShownDeploymentMessages[name][cond.Message]; !hasKey {
-						display.OutF("│   %s\n", color.New(color.FgBlue).Sprintf("↻  %s", cond.Message))
-						mt.ShownDeploymentMessages[name][cond.Message] = struct{}{}
+					if _, hasKey := mt.ShownDeploymentMessages[id][cond.Message]; !hasKey {
+						fmt.Fprintf(report, "│   %s\n", color.New(color.FgBlue).Sprintf("↻  %s", cond.Message))
+						mt.ShownDeploymentMessages[id][cond.Message] = struct{}{}
 					}
 				}
 			}
@@ -424,144 +1023,414 @@ func (mt *multitracker) PrintStatusReport() error {
 				}
 			}
 			if len(unreadyMsgs) > 0 {
-				display.OutF("│   %s\n", color.New(color.FgYellow).Sprintf("⌚ %s", strings.Join(unreadyMsgs, ", ")))
+				fmt.Fprintf(report, "│   %s\n", color.New(color.FgYellow).Sprintf("⌚ %s", strings.Join(unreadyMsgs, ", ")))
 			}
 
 			for _, cond := range status.ReadyStatus.ReadyConditions {
 				if cond.IsSatisfied {
-					if _, hasKey := mt.ShownDeploymentMessages[name][cond.Message]; !hasKey {
-						display.OutF("│   %s\n", color.New(color.FgGreen).Sprintf("✅ %s", cond.Message))
-						mt.ShownDeploymentMessages[name][cond.Message] = struct{}{}
+					if _, hasKey := mt.ShownDeploymentMessages[id][cond.Message]; !hasKey {
+						fmt.Fprintf(report, "│   %s\n", color.New(color.FgGreen).Sprintf("✅ %s", cond.Message))
+						mt.ShownDeploymentMessages[id][cond.Message] = struct{}{}
 					}
 				}
 			}
 
 			for podName, podStatus := range status.Pods {
 				if podStatus.IsFailed {
-					display.OutF("│   %s\n", color.New(color.FgRed).Sprintf("❌ pod/%s %s", podName, podStatus.FailedReason))
+					fmt.Fprintf(report, "│   %s\n", color.New(color.FgRed).Sprintf("❌ pod/%s %s", podName, podStatus.FailedReason))
 				}
 			}
 		}
 	}
 
-	for name, status := range mt.StatefulSetsStatuses {
-		display.OutF("├ sts/%s\n", name)
-		display.OutF("│   Replicas:%d ReadyReplicas:%d CurrentReplicas:%d UpdatedReplicas:%d\n", status.Replicas, status.ReadyReplicas, status.CurrentReplicas, status.UpdatedReplicas)
+	for id, status := range mt.StatefulSetsStatuses {
+		fmt.Fprintf(report, "├ %s\n", mt.resourceName(id))
+		mt.printResourceFailuresBudget(report, mt.TrackingStatefulSets, id, mt.StatefulSetsSpecs[id])
+		mt.printResourceRestarts(report, mt.TrackingStatefulSets, id, mt.StatefulSetsSpecs[id])
+		mt.printUnschedulablePods(report, status.Pods)
+		mt.printProbeFailures(report, status.Pods)
+		fmt.Fprintf(report, "│   Replicas:%d ReadyReplicas:%d CurrentReplicas:%d UpdatedReplicas:%d\n", status.Replicas, status.ReadyReplicas, status.CurrentReplicas, status.UpdatedReplicas)
 		if len(status.Conditions) > 0 {
-			display.OutF("│   Conditions:\n")
+			fmt.Fprintf(report, "│   Conditions:\n")
 		}
 		for _, cond := range status.Conditions {
-			display.OutF("│   - %s %s:%s", cond.LastTransitionTime, cond.Type, cond.Status)
+			fmt.Fprintf(report, "│   - %s %s:%s", cond.LastTransitionTime, cond.Type, cond.Status)
 			if cond.Reason != "" {
-				display.OutF(" %s", cond.Reason)
+				fmt.Fprintf(report, " %s", cond.Reason)
 			}
 			if cond.Message != "" {
-				display.OutF(" %s", cond.Message)
+				fmt.Fprintf(report, " %s", cond.Message)
 			}
-			display.OutF("\n")
+			fmt.Fprintf(report, "\n")
 		}
 	}
 
-	for name, status := range mt.DaemonSetsStatuses {
-		display.OutF("├ ds/%s\n", name)
-		display.OutF("│   CurrentNumberScheduled:%d NumberReady:%d NumberAvailable:%d NumberUnavailable:%d\n", status.CurrentNumberScheduled, status.NumberReady, status.NumberAvailable, status.NumberUnavailable)
+	for id, status := range mt.DaemonSetsStatuses {
+		fmt.Fprintf(report, "├ %s\n", mt.resourceName(id))
+		mt.printResourceFailuresBudget(report, mt.TrackingDaemonSets, id, mt.DaemonSetsSpecs[id])
+		mt.printResourceRestarts(report, mt.TrackingDaemonSets, id, mt.DaemonSetsSpecs[id])
+		mt.printUnschedulablePods(report, status.Pods)
+		mt.printProbeFailures(report, status.Pods)
+		fmt.Fprintf(report, "│   CurrentNumberScheduled:%d NumberReady:%d NumberAvailable:%d NumberUnavailable:%d\n", status.CurrentNumberScheduled, status.NumberReady, status.NumberAvailable, status.NumberUnavailable)
 		if len(status.Conditions) > 0 {
-			display.OutF("│   Conditions:\n")
+			fmt.Fprintf(report, "│   Conditions:\n")
 		}
 		for _, cond := range status.Conditions {
-			display.OutF("│   - %s %s:%s", cond.LastTransitionTime, cond.Type, cond.Status)
+			fmt.Fprintf(report, "│   - %s %s:%s", cond.LastTransitionTime, cond.Type, cond.Status)
 			if cond.Reason != "" {
-				display.OutF(" %s", cond.Reason)
+				fmt.Fprintf(report, " %s", cond.Reason)
 			}
 			if cond.Message != "" {
-				display.OutF(" %s", cond.Message)
+				fmt.Fprintf(report, " %s", cond.Message)
 			}
-			display.OutF("\n")
+			fmt.Fprintf(report, "\n")
 		}
 	}
 
-	for name, status := range mt.JobsStatuses {
-		display.OutF("├ job/%s\n", name)
-		display.OutF("│   Active:%d Succeeded:%d Failed:%d\n", status.Active, status.Succeeded, status.Failed)
-		display.OutF("│   StartTime:%s CompletionTime:%s\n", status.StartTime, status.CompletionTime)
+	for id, status := range mt.JobsStatuses {
+		fmt.Fprintf(report, "├ %s\n", mt.resourceName(id))
+		mt.printResourceFailuresBudget(report, mt.TrackingJobs, id, mt.JobsSpecs[id])
+		mt.printResourceRestarts(report, mt.TrackingJobs, id, mt.JobsSpecs[id])
+		mt.printUnschedulablePods(report, status.Pods)
+		mt.printProbeFailures(report, status.Pods)
+		fmt.Fprintf(report, "│   Active:%d Succeeded:%d Failed:%d\n", status.Active, status.Succeeded, status.Failed)
+		fmt.Fprintf(report, "│   StartTime:%s CompletionTime:%s\n", status.StartTime, status.CompletionTime)
 		if len(status.Conditions) > 0 {
-			display.OutF("│   Conditions:\n")
+			fmt.Fprintf(report, "│   Conditions:\n")
 		}
 		for _, cond := range status.Conditions {
-			display.OutF("│   - %s %s:%s", cond.LastTransitionTime, cond.Type, cond.Status)
+			fmt.Fprintf(report, "│   - %s %s:%s", cond.LastTransitionTime, cond.Type, cond.Status)
 			if cond.Reason != "" {
-				display.OutF(" %s", cond.Reason)
+				fmt.Fprintf(report, " %s", cond.Reason)
 			}
 			if cond.Message != "" {
-				display.OutF(" %s", cond.Message)
+				fmt.Fprintf(report, " %s", cond.Message)
 			}
-			display.OutF("\n")
+			fmt.Fprintf(report, "\n")
 		}
 	}
 
-	for name := range mt.TrackingPods {
-		if _, hasKey := mt.PodsStatuses[name]; hasKey {
+	for id := range mt.TrackingPods {
+		if _, hasKey := mt.PodsStatuses[id]; hasKey || mt.waitingResources[id] {
 			continue
 		}
-		display.OutF("├ po/%s status unavailable\n", name)
+		fmt.Fprintf(report, "├ %s status unavailable\n", mt.resourceName(id))
 	}
-	for name := range mt.TrackingDeployments {
-		if _, hasKey := mt.DeploymentsStatuses[name]; hasKey {
+	for id := range mt.TrackingDeployments {
+		if _, hasKey := mt.DeploymentsStatuses[id]; hasKey || mt.waitingResources[id] {
 			continue
 		}
-		display.OutF("├ deploy/%s status unavailable\n", name)
+		fmt.Fprintf(report, "├ %s status unavailable\n", mt.resourceName(id))
 	}
-	for name := range mt.TrackingStatefulSets {
-		if _, hasKey := mt.StatefulSetsStatuses[name]; hasKey {
+	for id := range mt.TrackingStatefulSets {
+		if _, hasKey := mt.StatefulSetsStatuses[id]; hasKey || mt.waitingResources[id] {
 			continue
 		}
-		display.OutF("├ sts/%s status unavailable\n", name)
+		fmt.Fprintf(report, "├ %s status unavailable\n", mt.resourceName(id))
 	}
-	for name := range mt.TrackingDaemonSets {
-		if _, hasKey := mt.DaemonSetsStatuses[name]; hasKey {
+	for id := range mt.TrackingDaemonSets {
+		if _, hasKey := mt.DaemonSetsStatuses[id]; hasKey || mt.waitingResources[id] {
 			continue
 		}
-		display.OutF("├ ds/%s status unavailable\n", name)
+		fmt.Fprintf(report, "├ %s status unavailable\n", mt.resourceName(id))
 	}
-	for name := range mt.TrackingJobs {
-		if _, hasKey := mt.JobsStatuses[name]; hasKey {
+	for id := range mt.TrackingJobs {
+		if _, hasKey := mt.JobsStatuses[id]; hasKey || mt.waitingResources[id] {
 			continue
 		}
-		display.OutF("├ job/%s status unavailable\n", name)
+		fmt.Fprintf(report, "├ %s status unavailable\n", mt.resourceName(id))
+	}
+
+	for _, id := range mt.resourcesOrder {
+		if mt.waitingResources[id] {
+			fmt.Fprintf(report, "├ %s %s\n", mt.resourceName(id), color.New(color.FgYellow).Sprintf("waiting for %s", strings.Join(mt.notReadyDependencies(id), ", ")))
+		} else if state := mt.resourcesStates(id.Kind)[id]; state != nil && state.IsReadyPendingDependencies {
+			fmt.Fprintf(report, "├ %s %s\n", mt.resourceName(id), color.New(color.FgYellow).Sprintf("is ready, waiting for %s", strings.Join(mt.notReadyDependencies(id), ", ")))
+		}
 	}
 
-	display.OutF("└ %s\n", caption)
+	fmt.Fprintf(report, "└ %s\n", caption)
+
+	mt.printer.StatusReport(report.String())
 
 	return nil
 }
 
-func (mt *multitracker) handleResourceFailure(resourcesStates map[string]*multitrackerResourceState, spec MultitrackSpec, reason string) error {
-	resourcesStates[spec.ResourceName].FailuresCount++
-	if resourcesStates[spec.ResourceName].FailuresCount <= *spec.AllowFailuresCount {
+// printUnschedulablePods prints scheduling failures breakdown of the unschedulable pods
+func (mt *multitracker) printUnschedulablePods(report io.Writer, podsStatuses map[string]pod.PodStatus) {
+	podsNames := []string{}
+	for podName := range podsStatuses {
+		podsNames = append(podsNames, podName)
+	}
+	sort.Strings(podsNames)
+
+	for _, podName := range podsNames {
+		failure, ok := pod.GetSchedulingFailure(podsStatuses[podName].PodStatus)
+		if !ok {
+			continue
+		}
+
+		if len(failure.Reasons) == 0 {
+			fmt.Fprintf(report, "│   %s\n", color.New(color.FgYellow).Sprintf("⌚ po/%s unschedulable for %s: %s", podName, time.Since(failure.Since).Truncate(time.Second), failure.Message))
+			continue
+		}
+
+		fmt.Fprintf(report, "│   %s\n", color.New(color.FgYellow).Sprintf("⌚ po/%s unschedulable for %s: %d/%d nodes available", podName, time.Since(failure.Since).Truncate(time.Second), failure.AvailableNodes, failure.TotalNodes))
+		for _, reason := range failure.Reasons {
+			fmt.Fprintf(report, "│     - %d %s\n", reason.Nodes, reason.Reason)
+		}
+	}
+}
+
+// printProbeFailures prints aggregated probe failures of the pods which are not ready
+func (mt *multitracker) printProbeFailures(report io.Writer, podsStatuses map[string]pod.PodStatus) {
+	podsNames := []string{}
+	for podName := range podsStatuses {
+		podsNames = append(podsNames, podName)
+	}
+	sort.Strings(podsNames)
+
+	for _, podName := range podsNames {
+		podStatus := podsStatuses[podName]
+		if isPodReady(podStatus) {
+			continue
+		}
+
+		for _, failure := range podStatus.ProbeFailures {
+			fmt.Fprintf(report, "│   %s\n", color.New(color.FgYellow).Sprintf("⚠  po/%s %s %s (first seen %s ago, last seen %s ago)", podName, failure.ContainerName, failure.Summary(), time.Since(failure.FirstSeen).Truncate(time.Second), time.Since(failure.LastSeen).Truncate(time.Second)))
+		}
+	}
+}
+
+func (mt *multitracker) printResourceRestarts(report io.Writer, resourcesStates map[ResourceID]*multitrackerResourceState, id ResourceID, spec MultitrackSpec) {
+	state, hasKey := resourcesStates[id]
+	if !hasKey || spec.MaxRestarts == nil || state.restartsCount() == 0 {
+		return
+	}
+
+	fmt.Fprintf(report, "│   %s\n", color.New(color.FgYellow).Sprintf("⚠  restarts: %d of %d allowed", state.restartsCount(), *spec.MaxRestarts))
+}
+
+func (mt *multitracker) printResourceFailuresBudget(report io.Writer, resourcesStates map[ResourceID]*multitrackerResourceState, id ResourceID, spec MultitrackSpec) {
+	state, hasKey := resourcesStates[id]
+	if !hasKey || state.IsFailed || state.FailuresCount == 0 {
+		return
+	}
+
+	fmt.Fprintf(report, "│   %s\n", color.New(color.FgYellow).Sprintf("⚠  failures budget: %s", state.failuresBudgetMessage(spec)))
+}
+
+// handleResourceFailure counts resource failure and fails the resource according to its FailMode
+// when AllowFailuresCount is exceeded and failures continue for FailureThresholdSeconds since the first failure.
+// PodName is the pod which caused the failure, if any.
+func (mt *multitracker) handleResourceFailure(resourcesStates map[ResourceID]*multitrackerResourceState, id ResourceID, spec MultitrackSpec, podName, reason string) error {
+	state, hasKey := resourcesStates[id]
+	if !hasKey || mt.isDeployEnded {
 		return nil
 	}
 
+	if state.FailuresCount == 0 {
+		state.FailuresWindowStartedAt = time.Now()
+	}
+	state.FailuresCount++
+	state.LastFailureReason = reason
+	if podName != "" {
+		state.FailedPods = appendElemIfNotExist(state.FailedPods, podName)
+	}
+	mt.addResourceFailure(id, podName, reason)
+
+	if !state.isFailuresBudgetExceeded(spec) {
+		return nil
+	}
+
+	return mt.failResource(resourcesStates, id, spec)
+}
+
+// handleResourcePodsStatuses resets failures budget of the resource when all pods failed since the last recovery
+// become ready (or gone), or fails the resource when failures budget is exceeded without recovery.
+func (mt *multitracker) handleResourcePodsStatuses(resourcesStates map[ResourceID]*multitrackerResourceState, id ResourceID, spec MultitrackSpec, podsStatuses map[string]pod.PodStatus) error {
+	state, hasKey := resourcesStates[id]
+	if !hasKey || state.FailuresCount == 0 {
+		return nil
+	}
+
+	if len(state.FailedPods) > 0 {
+		recovered := true
+		for _, podName := range state.FailedPods {
+			if podStatus, hasKey := podsStatuses[podName]; hasKey && !isPodRecovered(podStatus) {
+				recovered = false
+				break
+			}
+		}
+
+		if recovered {
+			state.resetFailuresBudget()
+			if spec.FailMode == HopeUntilEndOfDeployProcess {
+				state.IsFailed = false
+			}
+			return nil
+		}
+	}
+
+	if state.IsFailed || !state.isFailuresBudgetExceeded(spec) {
+		return nil
+	}
+
+	return mt.failResource(resourcesStates, id, spec)
+}
+
+func (mt *multitracker) failResource(resourcesStates map[ResourceID]*multitrackerResourceState, id ResourceID, spec MultitrackSpec) error {
+	resourcesStates[id].showSuppressedLogs(mt.printer)
+
 	if spec.FailMode == FailWholeDeployProcessImmediately {
-		resourcesStates[spec.ResourceName].IsFailed = true
-		resourcesStates[spec.ResourceName].LastFailureReason = reason
+		resourcesStates[id].IsFailed = true
+		mt.failDependentResources(id)
 		return tracker.StopTrack
 	} else if spec.FailMode == HopeUntilEndOfDeployProcess {
-		resourcesStates[spec.ResourceName].IsFailed = true
-		resourcesStates[spec.ResourceName].LastFailureReason = reason
-		// TODO: goroutine for this resource should be stopped somehow at the end of deploy process
+		// Tracking continues until the end of deploy process, resource may still recover
+		resourcesStates[id].IsFailed = true
+		mt.checkEndOfDeploy()
 		return nil
 	} else if spec.FailMode == IgnoreAndContinueDeployProcess {
-		delete(resourcesStates, spec.ResourceName)
+		delete(resourcesStates, id)
+		mt.results[id].State = ResourceIgnored
+		mt.failDependentResources(id)
 		return tracker.StopTrack
 	} else {
 		panic(fmt.Sprintf("bad fail mode: %s", spec.FailMode))
 	}
 }
 
-func displayContainerLogChunk(header string, spec MultitrackSpec, chunk *pod.ContainerLogChunk) {
+// handleContainerLogChunk shows filtered container log lines of the resource
+// and checks all lines of the chunk with FailOnLogRegex and SucceedOnLogRegex.
+// Logs of the ready pod are suppressed when ShowLogsUntil=PodIsReady.
+func (mt *multitracker) handleContainerLogChunk(resourcesStates map[ResourceID]*multitrackerResourceState, id ResourceID, spec MultitrackSpec, header, podName string, chunk *pod.ContainerLogChunk, podStatus pod.PodStatus) error {
+	if logLines := filterContainerLogLines(spec, chunk); len(logLines) > 0 {
+		event := mt.resourceEvent(id, display.LogEvent).WithPod(podName, chunk.ContainerName)
+
+		if spec.ShowLogsUntil == PodIsReady && isPodReady(podStatus) {
+			if state, hasKey := resourcesStates[id]; hasKey {
+				state.suppressLogLines(event, header, logLines)
+			}
+		} else {
+			mt.printer.LogLines(event, header, logLines)
+		}
+	}
+
+	return mt.handleContainerLogRegexes(resourcesStates, id, spec, podName, chunk)
+}
+
+// handlePodUnschedulable shows the scheduling failure of the pod
+func (mt *multitracker) handlePodUnschedulable(id ResourceID, event display.Event, podName string, failure pod.SchedulingFailure) {
+	msg := fmt.Sprintf("unschedulable: %s", failure.Summary())
+	if id.Kind != "po" {
+		msg = fmt.Sprintf("po/%s %s", podName, msg)
+	}
+	mt.printer.Message(event.WithPod(podName, "").WithMessage(failure.Message), fmt.Sprintf("# %s %s", mt.resourceName(id), msg))
+}
+
+// handleContainerTermination shows termination details and the last log lines of the crashed container instance
+func (mt *multitracker) handleContainerTermination(resourcesStates map[ResourceID]*multitrackerResourceState, id ResourceID, event display.Event, header string, termination pod.PodContainerTermination) error {
+	msg := fmt.Sprintf("po/%s container/%s terminated: %s", termination.PodName, termination.ContainerName, termination.Summary())
+	mt.printer.Message(event.WithType(display.ContainerTerminatedEvent).WithMessage(termination.Summary()), fmt.Sprintf("# %s %s", mt.resourceName(id), msg))
+
+	if len(termination.LogLines) > 0 {
+		mt.printer.LogLines(event.WithType(display.LogEvent), header, termination.LogLines)
+	}
+
+	if state, hasKey := resourcesStates[id]; hasKey {
+		state.LastContainerTermination = &termination
+	}
+
+	return nil
+}
+
+// handleContainerRestart shows the container restart and fails the resource when restarts of its pods containers exceed MaxRestarts
+func (mt *multitracker) handleContainerRestart(resourcesStates map[ResourceID]*multitrackerResourceState, id ResourceID, spec MultitrackSpec, event display.Event, restart pod.PodContainerRestart) error {
+	msg := fmt.Sprintf("po/%s container/%s restarted: %s", restart.PodName, restart.ContainerName, restart.Summary())
+	mt.printer.Message(event.WithType(display.ContainerRestartedEvent).WithMessage(restart.Summary()), fmt.Sprintf("# %s %s", mt.resourceName(id), msg))
+
+	state, hasKey := resourcesStates[id]
+	if !hasKey || state.IsFailed || mt.isDeployEnded {
+		return nil
+	}
+
+	if state.ContainersRestarts == nil {
+		state.ContainersRestarts = make(map[string]int32)
+	}
+	state.ContainersRestarts[fmt.Sprintf("%s/%s", restart.PodName, restart.ContainerName)] = restart.Restarts
+
+	if spec.MaxRestarts == nil || state.restartsCount() <= *spec.MaxRestarts {
+		return nil
+	}
+
+	reason := fmt.Sprintf("max restarts %d exceeded: %d restarts since tracking started, last po/%s container/%s", *spec.MaxRestarts, state.restartsCount(), restart.PodName, restart.ContainerName)
+	if restart.LastTermination != nil {
+		reason += fmt.Sprintf(": %s", restart.LastTermination.Summary())
+	}
+
+	mt.printer.Message(mt.resourceEvent(id, display.FailedEvent).WithMessage(reason), fmt.Sprintf("# %s failed: %s", mt.resourceName(id), reason))
+
+	state.LastFailureReason = reason
+	mt.addResourceFailure(id, restart.PodName, reason)
+
+	return mt.failResource(resourcesStates, id, spec)
+}
+
+// handleContainerLogRegexes handles resource failure when a log line matches FailOnLogRegex
+// and marks the resource ready when a log line matches SucceedOnLogRegex
+func (mt *multitracker) handleContainerLogRegexes(resourcesStates map[ResourceID]*multitrackerResourceState, id ResourceID, spec MultitrackSpec, podName string, chunk *pod.ContainerLogChunk) error {
+	failRegexp := containerLogRegexp(spec.FailOnLogRegex, spec.FailOnLogRegexByContainerName, chunk.ContainerName)
+	succeedRegexp := containerLogRegexp(spec.SucceedOnLogRegex, spec.SucceedOnLogRegexByContainerName, chunk.ContainerName)
+
+	if failRegexp == nil && succeedRegexp == nil {
+		return nil
+	}
+
+	for _, logLine := range chunk.LogLines {
+		state, hasKey := resourcesStates[id]
+		if !hasKey || state.IsFailed || state.IsReadyPendingDependencies {
+			return nil
+		}
+
+		if failRegexp != nil && failRegexp.MatchString(logLine.Message) {
+			reason := fmt.Sprintf("po/%s container/%s log line matched %q: %s", podName, chunk.ContainerName, failRegexp.String(), logLine.Message)
+
+			event := mt.resourceEvent(id, display.PodErrorEvent).WithPod(podName, chunk.ContainerName).WithMessage(reason)
+			mt.printer.Message(event, fmt.Sprintf("# %s %s", mt.resourceName(id), reason))
+
+			// Pod is not passed on purpose: the pod may be ready, which should not reset failures budget
+			if err := mt.handleResourceFailure(resourcesStates, id, spec, "", reason); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if succeedRegexp != nil && succeedRegexp.MatchString(logLine.Message) {
+			msg := fmt.Sprintf("po/%s container/%s log line matched %q", podName, chunk.ContainerName, succeedRegexp.String())
+
+			event := mt.resourceEvent(id, display.ReadyEvent).WithPod(podName, chunk.ContainerName).WithMessage(msg)
+			mt.printer.Message(event, fmt.Sprintf("# %s become READY: %s", mt.resourceName(id), msg))
+
+			return mt.handleResourceReadyCondition(resourcesStates, id, spec)
+		}
+	}
+
+	return nil
+}
+
+// containerLogRegexp returns the regexp for the container, regexp for all containers is used when not set
+func containerLogRegexp(regexpForAll *regexp.Regexp, regexpByContainerName map[string]*regexp.Regexp, containerName string) *regexp.Regexp {
+	if regexpByContainerName[containerName] != nil {
+		return regexpByContainerName[containerName]
+	}
+	return regexpForAll
+}
+
+func filterContainerLogLines(spec MultitrackSpec, chunk *pod.ContainerLogChunk) []display.LogLine {
 	for _, containerName := range spec.SkipLogsForContainers {
 		if containerName == chunk.ContainerName {
-			return
+			return nil
 		}
 	}
 
@@ -573,24 +1442,30 @@ func displayContainerLogChunk(header string, spec MultitrackSpec, chunk *pod.Con
 	}
 
 	if !showLogs {
-		return
+		return nil
 	}
 
-	var logRegexp *regexp.Regexp
-	if spec.LogWatchRegexByContainerName[chunk.ContainerName] != nil {
-		logRegexp = spec.LogWatchRegexByContainerName[chunk.ContainerName]
-	} else if spec.LogWatchRegex != nil {
-		logRegexp = spec.LogWatchRegex
+	logRegexp := containerLogRegexp(spec.LogWatchRegex, spec.LogWatchRegexByContainerName, chunk.ContainerName)
+
+	if logRegexp == nil && spec.LogMinLevel == display.UnknownLogLevel {
+		return chunk.LogLines
 	}
 
-	if logRegexp != nil {
-		for _, logLine := range chunk.LogLines {
-			message := logRegexp.FindString(logLine.Message)
-			if message != "" {
-				display.OutputLogLines(header, []display.LogLine{logLine})
+	logLines := []display.LogLine{}
+	for _, logLine := range chunk.LogLines {
+		if logRegexp != nil && logRegexp.FindString(logLine.Message) == "" {
+			continue
+		}
+
+		if spec.LogMinLevel != display.UnknownLogLevel {
+			level := display.LogLineLevel(logLine.Message)
+			if level != display.UnknownLogLevel && level < spec.LogMinLevel {
+				continue
 			}
 		}
-	} else {
-		display.OutputLogLines(header, chunk.LogLines)
+
+		logLines = append(logLines, logLine)
 	}
+
+	return logLines
 }
@@ -0,0 +1,439 @@
+package multitrack
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/flant/kubedog/pkg/display"
+	"github.com/flant/kubedog/pkg/tracker"
+	"github.com/flant/kubedog/pkg/tracker/pod"
+	"github.com/flant/kubedog/pkg/tracker/trackertest"
+)
+
+func TestMultitrackStopsHopingTrackersAtTheEndOfDeploy(t *testing.T) {
+	// Pods without running containers: the fake clientset cannot stream logs.
+	// Pods are in different namespaces as the fake clientset ignores field selectors of trackers.
+	kube := fake.NewSimpleClientset(
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "hoping", Namespace: "myns"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Image: "ubuntu:nonexistent"}}},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "main",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "back-off pulling image"}},
+				}},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "otherns"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Image: "ubuntu"}}},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		},
+	)
+
+	goroutinesBefore := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	specs := MultitrackSpecs{Pods: []MultitrackSpec{
+		{ResourceName: "hoping", Namespace: "myns", FailMode: HopeUntilEndOfDeployProcess, AllowFailuresCount: new(int)},
+		{ResourceName: "ready", Namespace: "otherns"},
+	}}
+	opts := MultitrackOptions{Options: tracker.Options{ParentContext: ctx, Printer: display.NewTextPrinter(ioutil.Discard)}}
+
+	resultChan := make(chan *MultitrackResult, 1)
+	go func() {
+		resultChan <- MultitrackWithResult(kube, specs, opts)
+	}()
+
+	var result *MultitrackResult
+	select {
+	case result = <-resultChan:
+	case <-time.After(30 * time.Second):
+		t.Fatal("multitrack has not returned at the end of deploy process")
+	}
+
+	if result.Err == nil {
+		t.Errorf("expected error of the failed hoping resource")
+	}
+	for id, expected := range map[ResourceID]ResourceState{
+		{Namespace: "myns", Kind: "po", Name: "hoping"}:   ResourceFailed,
+		{Namespace: "otherns", Kind: "po", Name: "ready"}: ResourceReady,
+	} {
+		if res, ok := result.Resource(id); !ok || res.State != expected {
+			t.Errorf("expected %s to be %s, got %#v", id, expected, res)
+		}
+	}
+
+	// Context is not cancelled yet: hoping tracker should be stopped by the multitracker itself
+	trackertest.WaitGoroutines(t, goroutinesBefore)
+}
+
+func TestMultitrackFailuresBudget(t *testing.T) {
+	failingPod := map[string]pod.PodStatus{"mypod": {PodStatus: corev1.PodStatus{Phase: corev1.PodPending}}}
+	readyPod := map[string]pod.PodStatus{"mypod": readyPodStatus()}
+
+	t.Run("allowed failures count", func(t *testing.T) {
+		mt, id, _ := newTestMultitracker(MultitrackSpec{AllowFailuresCount: intPtr(2)})
+		state := mt.TrackingPods[id]
+
+		for i := 0; i < 2; i++ {
+			checkError(t, handlePodFailure(mt, id), "")
+		}
+		if state.IsFailed || state.FailuresCount != 2 {
+			t.Fatalf("expected 2 allowed failures, got %#v", state)
+		}
+
+		if err := handlePodFailure(mt, id); err != tracker.StopTrack {
+			t.Errorf("expected tracker to be stopped, got %v", err)
+		}
+		if !state.IsFailed {
+			t.Errorf("expected resource to be failed after 3 failures")
+		}
+	})
+
+	t.Run("failure threshold", func(t *testing.T) {
+		mt, id, _ := newTestMultitracker(MultitrackSpec{AllowFailuresCount: new(int), FailureThresholdSeconds: intPtr(60)})
+		state := mt.TrackingPods[id]
+
+		checkError(t, handlePodFailure(mt, id), "")
+		checkError(t, mt.handleResourcePodsStatuses(mt.TrackingPods, id, mt.PodsSpecs[id], failingPod), "")
+		if state.IsFailed {
+			t.Fatalf("expected resource not to be failed during failure threshold")
+		}
+
+		state.FailuresWindowStartedAt = state.FailuresWindowStartedAt.Add(-time.Minute)
+		if err := mt.handleResourcePodsStatuses(mt.TrackingPods, id, mt.PodsSpecs[id], failingPod); err != tracker.StopTrack {
+			t.Errorf("expected tracker to be stopped, got %v", err)
+		}
+		if !state.IsFailed {
+			t.Errorf("expected resource to be failed after failure threshold")
+		}
+	})
+
+	t.Run("recovery resets failures budget", func(t *testing.T) {
+		mt, id, _ := newTestMultitracker(MultitrackSpec{AllowFailuresCount: intPtr(1), FailureThresholdSeconds: intPtr(60)})
+		state := mt.TrackingPods[id]
+
+		checkError(t, handlePodFailure(mt, id), "")
+		checkError(t, mt.handleResourcePodsStatuses(mt.TrackingPods, id, mt.PodsSpecs[id], readyPod), "")
+		if state.FailuresCount != 0 || !state.FailuresWindowStartedAt.IsZero() || len(state.FailedPods) != 0 {
+			t.Fatalf("expected failures budget to be reset, got %#v", state)
+		}
+
+		checkError(t, handlePodFailure(mt, id), "")
+		if state.IsFailed || state.FailuresCount != 1 || state.FailuresWindowStartedAt.IsZero() {
+			t.Errorf("expected new failures window, got %#v", state)
+		}
+		if failuresCount := mt.results[id].FailuresCount; failuresCount != 2 {
+			t.Errorf("expected 2 failures in the result, got %d", failuresCount)
+		}
+	})
+
+	t.Run("hoping resource recovers", func(t *testing.T) {
+		mt, id, _ := newTestMultitracker(MultitrackSpec{FailMode: HopeUntilEndOfDeployProcess, AllowFailuresCount: new(int)})
+		state := mt.TrackingPods[id]
+
+		checkError(t, handlePodFailure(mt, id), "")
+		if !state.IsFailed {
+			t.Fatalf("expected resource to be failed")
+		}
+
+		checkError(t, mt.handleResourcePodsStatuses(mt.TrackingPods, id, mt.PodsSpecs[id], readyPod), "")
+		if state.IsFailed || state.FailuresCount != 0 {
+			t.Errorf("expected resource to recover, got %#v", state)
+		}
+	})
+}
+
+func TestMultitrackShowLogsUntil(t *testing.T) {
+	notReadyPod := pod.PodStatus{PodStatus: corev1.PodStatus{Phase: corev1.PodRunning}}
+
+	tests := []struct {
+		name          string
+		showLogsUntil DeployCondition
+		podStatus     pod.PodStatus
+		suppressed    bool
+	}{
+		{name: "pod is not ready", showLogsUntil: PodIsReady, podStatus: notReadyPod},
+		{name: "pod is ready", showLogsUntil: PodIsReady, podStatus: readyPodStatus(), suppressed: true},
+		{name: "until controller is ready", showLogsUntil: ControllerIsReady, podStatus: readyPodStatus()},
+		{name: "until end of deploy", showLogsUntil: EndOfDeploy, podStatus: readyPodStatus()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mt, id, out := newTestMultitracker(MultitrackSpec{ShowLogsUntil: test.showLogsUntil})
+
+			chunk := &pod.ContainerLogChunk{ContainerName: "main", LogLines: []display.LogLine{{Timestamp: "2019-01-01T00:00:00Z", Message: "listening on :8080"}}}
+			checkError(t, mt.handleContainerLogChunk(mt.TrackingPods, id, mt.PodsSpecs[id], "po/mypod", "mypod", chunk, test.podStatus), "")
+
+			if shown := strings.Contains(out.String(), "listening on :8080"); shown == test.suppressed {
+				t.Fatalf("expected log line suppressed %v, got output %q", test.suppressed, out.String())
+			}
+			if !test.suppressed {
+				return
+			}
+
+			// Suppressed logs are shown when the resource fails
+			checkError(t, handlePodFailure(mt, id), "")
+			if err := handlePodFailure(mt, id); err != tracker.StopTrack {
+				t.Fatalf("expected tracker to be stopped, got %v", err)
+			}
+			if !strings.Contains(out.String(), "listening on :8080") {
+				t.Errorf("expected suppressed log line to be shown on failure, got output %q", out.String())
+			}
+		})
+	}
+}
+
+func TestSuppressLogLinesLimit(t *testing.T) {
+	state := &multitrackerResourceState{}
+	for chunk := 0; chunk < 3; chunk++ {
+		logLines := []display.LogLine{}
+		for i := 0; i < 400; i++ {
+			logLines = append(logLines, display.LogLine{Message: fmt.Sprintf("line %d", chunk*400+i)})
+		}
+		state.suppressLogLines(display.Event{}, "po/mypod", logLines)
+	}
+
+	if state.SuppressedLogLinesCount != maxSuppressedLogLines {
+		t.Fatalf("expected %d suppressed lines, got %d", maxSuppressedLogLines, state.SuppressedLogLinesCount)
+	}
+	if first := state.SuppressedLogs[0].LogLines[0].Message; first != "line 200" {
+		t.Errorf("expected the last lines to be kept starting with %q, got %q", "line 200", first)
+	}
+}
+
+func TestHandleContainerLogRegexes(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          MultitrackSpec
+		containerName string
+		logLines      []string
+		failuresCount int
+		failed        bool
+		ready         bool
+	}{
+		{
+			name:          "fail regex",
+			spec:          MultitrackSpec{AllowFailuresCount: new(int), FailOnLogRegex: regexp.MustCompile("FATAL")},
+			containerName: "main",
+			logLines:      []string{"starting", "FATAL: no database"},
+			failuresCount: 1,
+			failed:        true,
+		},
+		{
+			name:          "fail regex within allowed failures",
+			spec:          MultitrackSpec{AllowFailuresCount: intPtr(2), FailOnLogRegex: regexp.MustCompile("FATAL")},
+			containerName: "main",
+			logLines:      []string{"FATAL: no database", "FATAL: no cache"},
+			failuresCount: 2,
+		},
+		{
+			name: "fail regex of the container",
+			spec: MultitrackSpec{
+				AllowFailuresCount:            new(int),
+				FailOnLogRegex:                regexp.MustCompile("FATAL"),
+				FailOnLogRegexByContainerName: map[string]*regexp.Regexp{"sidecar": regexp.MustCompile("panic:")},
+			},
+			containerName: "sidecar",
+			logLines:      []string{"FATAL: expected by sidecar", "panic: boom"},
+			failuresCount: 1,
+			failed:        true,
+		},
+		{
+			name:          "succeed regex",
+			spec:          MultitrackSpec{FailOnLogRegex: regexp.MustCompile("FATAL"), SucceedOnLogRegex: regexp.MustCompile("migrations applied")},
+			containerName: "main",
+			logLines:      []string{"applying migrations", "all migrations applied", "FATAL: after success"},
+			ready:         true,
+		},
+		{
+			name:          "fail regex before succeed regex",
+			spec:          MultitrackSpec{AllowFailuresCount: new(int), FailOnLogRegex: regexp.MustCompile("FATAL"), SucceedOnLogRegex: regexp.MustCompile("ready")},
+			containerName: "main",
+			logLines:      []string{"FATAL: not ready", "ready"},
+			failuresCount: 1,
+			failed:        true,
+		},
+		{
+			name:          "no match",
+			spec:          MultitrackSpec{FailOnLogRegex: regexp.MustCompile("FATAL"), SucceedOnLogRegex: regexp.MustCompile("ready")},
+			containerName: "main",
+			logLines:      []string{"starting", "listening on :8080"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mt, id, _ := newTestMultitracker(test.spec)
+			state := mt.TrackingPods[id]
+
+			chunk := &pod.ContainerLogChunk{ContainerName: test.containerName}
+			for _, line := range test.logLines {
+				chunk.LogLines = append(chunk.LogLines, display.LogLine{Message: line})
+			}
+
+			err := mt.handleContainerLogRegexes(mt.TrackingPods, id, mt.PodsSpecs[id], "mypod", chunk)
+			if expected := test.failed || test.ready; (err == tracker.StopTrack) != expected {
+				t.Fatalf("expected tracker stopped %v, got %v", expected, err)
+			}
+
+			if state.IsFailed != test.failed || state.FailuresCount != test.failuresCount {
+				t.Errorf("expected failed %v with %d failures, got %#v", test.failed, test.failuresCount, state)
+			}
+			if isReady := mt.results[id].State == ResourceReady; isReady != test.ready {
+				t.Errorf("expected ready %v, got result %#v", test.ready, mt.results[id])
+			}
+		})
+	}
+}
+
+func TestFailOnLogRegexIsNotResetByReadyPod(t *testing.T) {
+	mt, id, _ := newTestMultitracker(MultitrackSpec{AllowFailuresCount: intPtr(1), FailOnLogRegex: regexp.MustCompile("FATAL")})
+	state := mt.TrackingPods[id]
+
+	chunk := &pod.ContainerLogChunk{ContainerName: "main", LogLines: []display.LogLine{{Message: "FATAL: no database"}}}
+	checkError(t, mt.handleContainerLogRegexes(mt.TrackingPods, id, mt.PodsSpecs[id], "mypod", chunk), "")
+	checkError(t, mt.handleResourcePodsStatuses(mt.TrackingPods, id, mt.PodsSpecs[id], map[string]pod.PodStatus{"mypod": readyPodStatus()}), "")
+	if state.FailuresCount != 1 {
+		t.Fatalf("expected failure of the ready pod to be kept, got %#v", state)
+	}
+
+	if err := mt.handleContainerLogRegexes(mt.TrackingPods, id, mt.PodsSpecs[id], "mypod", chunk); err != tracker.StopTrack {
+		t.Errorf("expected tracker to be stopped, got %v", err)
+	}
+	if !strings.Contains(state.LastFailureReason, `po/mypod container/main log line matched "FATAL": FATAL: no database`) {
+		t.Errorf("unexpected failure reason %q", state.LastFailureReason)
+	}
+}
+
+func TestHandleContainerRestartMaxRestarts(t *testing.T) {
+	type restart struct {
+		container string
+		restarts  int32
+	}
+
+	tests := []struct {
+		name        string
+		spec        MultitrackSpec
+		restarts    []restart
+		failed      bool
+		stopped     bool
+		restartsSum int
+	}{
+		{
+			name:        "no limit",
+			restarts:    []restart{{"main", 1}, {"main", 5}, {"sidecar", 3}},
+			restartsSum: 8,
+		},
+		{
+			name:        "restarts of the container are not summed",
+			spec:        MultitrackSpec{MaxRestarts: intPtr(2)},
+			restarts:    []restart{{"main", 1}, {"main", 2}},
+			restartsSum: 2,
+		},
+		{
+			name:        "restarts of all containers exceed the limit",
+			spec:        MultitrackSpec{MaxRestarts: intPtr(2)},
+			restarts:    []restart{{"main", 2}, {"sidecar", 1}},
+			failed:      true,
+			stopped:     true,
+			restartsSum: 3,
+		},
+		{
+			name:        "no restarts allowed",
+			spec:        MultitrackSpec{MaxRestarts: new(int)},
+			restarts:    []restart{{"main", 1}},
+			failed:      true,
+			stopped:     true,
+			restartsSum: 1,
+		},
+		{
+			name:        "hoping resource keeps tracking",
+			spec:        MultitrackSpec{MaxRestarts: intPtr(1), FailMode: HopeUntilEndOfDeployProcess},
+			restarts:    []restart{{"main", 2}, {"main", 3}},
+			failed:      true,
+			restartsSum: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mt, id, _ := newTestMultitracker(test.spec)
+			state := mt.TrackingPods[id]
+
+			var err error
+			for _, r := range test.restarts {
+				containerRestart := pod.PodContainerRestart{
+					PodName: "mypod",
+					ContainerRestart: pod.ContainerRestart{
+						ContainerName:   r.container,
+						Restarts:        r.restarts,
+						LastTermination: &pod.ContainerTermination{ContainerName: r.container, ExitCode: 1, Reason: "Error"},
+					},
+				}
+				if err = mt.handleContainerRestart(mt.TrackingPods, id, mt.PodsSpecs[id], mt.resourceEvent(id, display.ContainerRestartedEvent), containerRestart); err != nil {
+					break
+				}
+			}
+
+			if (err == tracker.StopTrack) != test.stopped {
+				t.Errorf("expected tracker stopped %v, got %v", test.stopped, err)
+			}
+			if state.IsFailed != test.failed {
+				t.Errorf("expected failed %v, got %#v", test.failed, state)
+			}
+			if count := state.restartsCount(); count != test.restartsSum {
+				t.Errorf("expected %d restarts, got %d", test.restartsSum, count)
+			}
+
+			if test.failed {
+				expected := fmt.Sprintf("max restarts %d exceeded: %d restarts since tracking started", *test.spec.MaxRestarts, test.restartsSum)
+				if !strings.HasPrefix(state.LastFailureReason, expected) || !strings.HasSuffix(state.LastFailureReason, "exit code 1, reason Error") {
+					t.Errorf("expected failure reason %q with the last termination, got %q", expected, state.LastFailureReason)
+				}
+			}
+		})
+	}
+}
+
+// newTestMultitracker makes multitracker of the pod myns/mypod with the spec and returns its output, trackers are not started
+func newTestMultitracker(spec MultitrackSpec) (*multitracker, ResourceID, *bytes.Buffer) {
+	spec.ResourceName = "mypod"
+	spec.Namespace = "myns"
+
+	out := &bytes.Buffer{}
+	opts := MultitrackOptions{Options: tracker.Options{Printer: display.NewTextPrinter(out)}}
+	mt := newMultitracker(fake.NewSimpleClientset(), MultitrackSpecs{Pods: []MultitrackSpec{spec}}, opts)
+
+	return mt, ResourceID{Namespace: "myns", Kind: "po", Name: "mypod"}, out
+}
+
+func handlePodFailure(mt *multitracker, id ResourceID) error {
+	return mt.handleResourceFailure(mt.TrackingPods, id, mt.PodsSpecs[id], id.Name, "ImagePullBackOff: back-off pulling image")
+}
+
+func readyPodStatus() pod.PodStatus {
+	return pod.PodStatus{PodStatus: corev1.PodStatus{
+		Phase:      corev1.PodRunning,
+		Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+	}}
+}
@@ -10,140 +10,215 @@ import (
 
 func (mt *multitracker) TrackPod(kube kubernetes.Interface, spec MultitrackSpec, opts MultitrackOptions) error {
 	feed := pod.NewFeed()
+	id := ResourceID{Namespace: spec.Namespace, Kind: "po", Name: spec.ResourceName}
 
 	feed.OnAdded(func() error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.podAdded(spec, feed)
+		if err := mt.applyObjectAnnotations(id, &spec, feed.GetStatus().Annotations); err != nil {
+			return err
+		}
+		return mt.podAdded(id, spec, feed)
 	})
 	feed.OnSucceeded(func() error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.podSucceeded(spec, feed)
+		return mt.podSucceeded(id, spec, feed)
 	})
 	feed.OnFailed(func(reason string) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.podFailed(spec, feed, reason)
+		return mt.podFailed(id, spec, feed, reason)
 	})
 	feed.OnReady(func() error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.podReady(spec, feed)
+		return mt.podReady(id, spec, feed)
 	})
 	feed.OnEventMsg(func(msg string) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.podEventMsg(spec, feed, msg)
+		return mt.podEventMsg(id, spec, feed, msg)
 	})
 	feed.OnContainerError(func(containerError pod.ContainerError) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.podContainerError(spec, feed, containerError)
+		return mt.podContainerError(id, spec, feed, containerError)
 	})
 	feed.OnContainerLogChunk(func(chunk *pod.ContainerLogChunk) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.podContainerLogChunk(spec, feed, chunk)
+		return mt.podContainerLogChunk(id, spec, feed, chunk)
+	})
+	feed.OnContainerTermination(func(termination pod.ContainerTermination) error {
+		mt.handlerMux.Lock()
+		defer mt.handlerMux.Unlock()
+		return mt.podContainerTermination(id, spec, feed, termination)
+	})
+	feed.OnUnschedulable(func(failure pod.SchedulingFailure) error {
+		mt.handlerMux.Lock()
+		defer mt.handlerMux.Unlock()
+		return mt.podUnschedulable(id, spec, feed, failure)
+	})
+	feed.OnContainerRestart(func(restart pod.ContainerRestart) error {
+		mt.handlerMux.Lock()
+		defer mt.handlerMux.Unlock()
+		return mt.podContainerRestart(id, spec, feed, restart)
 	})
 	feed.OnStatusReport(func(status pod.PodStatus) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.podStatusReport(spec, feed, status)
+		return mt.podStatusReport(id, spec, feed, status)
 	})
 
 	return feed.Track(spec.ResourceName, spec.Namespace, kube, opts.Options)
 }
 
-func (mt *multitracker) podAdded(spec MultitrackSpec, feed pod.Feed) error {
+func (mt *multitracker) podAdded(id ResourceID, spec MultitrackSpec, feed pod.Feed) error {
 	if debug() {
 		fmt.Printf("-- podAdded %#v\n", spec)
 	}
 
-	display.OutF("# po/%s added\n", spec.ResourceName)
+	mt.PodsSpecs[id] = spec
+
+	mt.printer.Message(mt.resourceEvent(id, display.AddedEvent), fmt.Sprintf("# %s added", mt.resourceName(id)))
 
 	return nil
 }
 
-func (mt *multitracker) podSucceeded(spec MultitrackSpec, feed pod.Feed) error {
+func (mt *multitracker) podSucceeded(id ResourceID, spec MultitrackSpec, feed pod.Feed) error {
 	if debug() {
 		fmt.Printf("-- podSucceeded %#v\n", spec)
 	}
 
-	mt.PodsStatuses[spec.ResourceName] = feed.GetStatus()
+	mt.PodsStatuses[id] = feed.GetStatus()
 
-	display.OutF("# po/%s succeeded\n", spec.ResourceName)
+	mt.printer.Message(mt.resourceEvent(id, display.SucceededEvent), fmt.Sprintf("# %s succeeded", mt.resourceName(id)))
 
-	return mt.handleResourceReadyCondition(mt.TrackingPods, spec)
+	return mt.handleResourceReadyCondition(mt.TrackingPods, id, spec)
 }
 
-func (mt *multitracker) podFailed(spec MultitrackSpec, feed pod.Feed, reason string) error {
+func (mt *multitracker) podFailed(id ResourceID, spec MultitrackSpec, feed pod.Feed, reason string) error {
 	if debug() {
 		fmt.Printf("-- podFailed %#v %#v\n", spec, reason)
 	}
 
-	fmt.Fprintf(display.Out, "# po/%s failed: %s\n", spec.ResourceName, reason)
+	if !mt.isResourceTracked(mt.TrackingPods, id) {
+		return nil
+	}
 
-	return mt.handleResourceFailure(mt.TrackingPods, spec, reason)
+	mt.printer.Message(mt.resourceEvent(id, display.FailedEvent).WithMessage(reason), fmt.Sprintf("# %s failed: %s", mt.resourceName(id), reason))
+
+	return mt.handleResourceFailure(mt.TrackingPods, id, spec, spec.ResourceName, reason)
 }
 
-func (mt *multitracker) podReady(spec MultitrackSpec, feed pod.Feed) error {
+func (mt *multitracker) podReady(id ResourceID, spec MultitrackSpec, feed pod.Feed) error {
 	if debug() {
 		fmt.Printf("-- podReady %#v\n", spec)
 	}
 
-	mt.PodsStatuses[spec.ResourceName] = feed.GetStatus()
+	if !mt.isResourceTracked(mt.TrackingPods, id) {
+		return nil
+	}
 
-	display.OutF("# po/%s become READY\n", spec.ResourceName)
+	mt.PodsStatuses[id] = feed.GetStatus()
 
-	delete(mt.TrackingPods, spec.ResourceName)
+	mt.printer.Message(mt.resourceEvent(id, display.ReadyEvent), fmt.Sprintf("# %s become READY", mt.resourceName(id)))
 
-	return mt.handleResourceReadyCondition(mt.TrackingPods, spec)
+	return mt.handleResourceReadyCondition(mt.TrackingPods, id, spec)
 }
 
-func (mt *multitracker) podEventMsg(spec MultitrackSpec, feed pod.Feed, msg string) error {
+func (mt *multitracker) podEventMsg(id ResourceID, spec MultitrackSpec, feed pod.Feed, msg string) error {
 	if debug() {
 		fmt.Printf("-- podEventMsg %#v %#v\n", spec, msg)
 	}
 
-	display.OutF("# po/%s event: %s\n", spec.ResourceName, msg)
+	mt.printer.Message(mt.resourceEvent(id, display.EventMsgEvent).WithMessage(msg), fmt.Sprintf("# %s event: %s", mt.resourceName(id), msg))
 
 	return nil
 }
 
-func (mt *multitracker) podContainerError(spec MultitrackSpec, feed pod.Feed, containerError pod.ContainerError) error {
+func (mt *multitracker) podContainerError(id ResourceID, spec MultitrackSpec, feed pod.Feed, containerError pod.ContainerError) error {
 	if debug() {
 		fmt.Printf("-- podContainerError %#v %#v\n", spec, containerError)
 	}
 
+	if !mt.isResourceTracked(mt.TrackingPods, id) {
+		return nil
+	}
+
 	reason := fmt.Sprintf("container/%s error: %s", containerError.ContainerName, containerError.Message)
 
-	display.OutF("# po/%s %s\n", spec.ResourceName, reason)
+	event := mt.resourceEvent(id, display.PodErrorEvent).WithPod(spec.ResourceName, containerError.ContainerName).WithMessage(containerError.Message)
+	mt.printer.Message(event, fmt.Sprintf("# %s %s", mt.resourceName(id), reason))
 
-	return mt.handleResourceFailure(mt.TrackingPods, spec, reason)
+	return mt.handleResourceFailure(mt.TrackingPods, id, spec, spec.ResourceName, reason)
 }
 
-func (mt *multitracker) podContainerLogChunk(spec MultitrackSpec, feed pod.Feed, chunk *pod.ContainerLogChunk) error {
+func (mt *multitracker) podContainerLogChunk(id ResourceID, spec MultitrackSpec, feed pod.Feed, chunk *pod.ContainerLogChunk) error {
 	if debug() {
 		fmt.Printf("-- podContainerLogChunk %#v %#v\n", spec, chunk)
 	}
 
-	header := podContainerLogChunkHeader(spec.ResourceName, chunk)
-	displayContainerLogChunk(header, spec, chunk)
-
-	return nil
+	header := fmt.Sprintf("%s %s", mt.resourceName(id), chunk.ContainerName)
+	return mt.handleContainerLogChunk(mt.TrackingPods, id, spec, header, spec.ResourceName, chunk, mt.PodsStatuses[id])
 }
 
 func podContainerLogChunkHeader(podName string, chunk *pod.ContainerLogChunk) string {
 	return fmt.Sprintf("po/%s %s", podName, chunk.ContainerName)
 }
 
-func (mt *multitracker) podStatusReport(spec MultitrackSpec, feed pod.Feed, status pod.PodStatus) error {
+func (mt *multitracker) podContainerTermination(id ResourceID, spec MultitrackSpec, feed pod.Feed, termination pod.ContainerTermination) error {
 	if debug() {
-		fmt.Printf("-- podStatusReport %#v %#v\n", spec, status)
+		fmt.Printf("-- podContainerTermination %#v %#v\n", spec, termination)
 	}
 
-	mt.PodsStatuses[spec.ResourceName] = status
+	if !mt.isResourceTracked(mt.TrackingPods, id) {
+		return nil
+	}
+
+	podTermination := pod.PodContainerTermination{ContainerTermination: termination, PodName: spec.ResourceName}
+	event := mt.resourceEvent(id, display.ContainerTerminatedEvent).WithPod(spec.ResourceName, termination.ContainerName)
+	header := fmt.Sprintf("%s %s (terminated)", mt.resourceName(id), termination.ContainerName)
+	return mt.handleContainerTermination(mt.TrackingPods, id, event, header, podTermination)
+}
+
+func (mt *multitracker) podUnschedulable(id ResourceID, spec MultitrackSpec, feed pod.Feed, failure pod.SchedulingFailure) error {
+	if debug() {
+		fmt.Printf("-- podUnschedulable %#v %#v\n", spec, failure)
+	}
+
+	if !mt.isResourceTracked(mt.TrackingPods, id) {
+		return nil
+	}
+
+	mt.handlePodUnschedulable(id, mt.resourceEvent(id, display.UnschedulableEvent), spec.ResourceName, failure)
 
 	return nil
 }
+
+func (mt *multitracker) podContainerRestart(id ResourceID, spec MultitrackSpec, feed pod.Feed, restart pod.ContainerRestart) error {
+	if debug() {
+		fmt.Printf("-- podContainerRestart %#v %#v\n", spec, restart)
+	}
+
+	if !mt.isResourceTracked(mt.TrackingPods, id) {
+		return nil
+	}
+
+	podRestart := pod.PodContainerRestart{ContainerRestart: restart, PodName: spec.ResourceName}
+	event := mt.resourceEvent(id, display.ContainerRestartedEvent).WithPod(spec.ResourceName, restart.ContainerName)
+	return mt.handleContainerRestart(mt.TrackingPods, id, spec, event, podRestart)
+}
+
+func (mt *multitracker) podStatusReport(id ResourceID, spec MultitrackSpec, feed pod.Feed, status pod.PodStatus) error {
+	if debug() {
+		fmt.Printf("-- podStatusReport %#v %#v\n", spec, status)
+	}
+
+	mt.PodsStatuses[id] = status
+	mt.handleResourceProgress(id)
+	mt.printer.ResourceStatus(mt.resourceEvent(id, display.StatusReportEvent).WithStatus(status))
+
+	return mt.handleResourcePodsStatuses(mt.TrackingPods, id, spec, map[string]pod.PodStatus{spec.ResourceName: status})
+}
@@ -0,0 +1,168 @@
+package multitrack
+
+import (
+	"sort"
+	"time"
+
+	"github.com/flant/kubedog/pkg/tracker/daemonset"
+	"github.com/flant/kubedog/pkg/tracker/deployment"
+	"github.com/flant/kubedog/pkg/tracker/job"
+	"github.com/flant/kubedog/pkg/tracker/pod"
+	"github.com/flant/kubedog/pkg/tracker/statefulset"
+)
+
+// ResourceState is the final state of the tracked resource
+type ResourceState string
+
+const (
+	ResourceReady  ResourceState = "ready"
+	ResourceFailed ResourceState = "failed"
+	// ResourceIgnored is the state of the resource failed with IgnoreAndContinueDeployProcess fail mode
+	ResourceIgnored ResourceState = "ignored"
+	// ResourceInterrupted is the state of the resource which tracking has been stopped before the resource
+	// became ready or failed: because of the failure of other resource or cancelled parent context
+	ResourceInterrupted ResourceState = "interrupted"
+)
+
+// MultitrackResult is the outcome of Multitrack
+type MultitrackResult struct {
+	// Resources are results of tracked resources in the order of specs
+	Resources []ResourceResult
+
+	// Err is the same error as returned by Multitrack
+	Err error
+}
+
+// Resource returns result of the resource with the specified id
+func (result *MultitrackResult) Resource(id ResourceID) (ResourceResult, bool) {
+	for _, res := range result.Resources {
+		if res.ID == id {
+			return res, true
+		}
+	}
+	return ResourceResult{}, false
+}
+
+// FailedResources returns results of failed resources
+func (result *MultitrackResult) FailedResources() []ResourceResult {
+	res := []ResourceResult{}
+	for _, resourceResult := range result.Resources {
+		if resourceResult.State == ResourceFailed {
+			res = append(res, resourceResult)
+		}
+	}
+	return res
+}
+
+type ResourceResult struct {
+	ID    ResourceID
+	State ResourceState
+
+	// FailureReasons are distinct failure reasons of the resource in the order of occurrence
+	FailureReasons []string
+	// FailuresCount is the total count of resource failures including the failures resource has recovered from
+	FailuresCount int
+
+	// TimeToReady is the time since the start of tracking until the resource became ready
+	TimeToReady time.Duration
+
+	// Last known status of the resource, only the field of the resource kind is set
+	PodStatus         *pod.PodStatus
+	DeploymentStatus  *deployment.DeploymentStatus
+	StatefulSetStatus *statefulset.StatefulSetStatus
+	DaemonSetStatus   *daemonset.DaemonSetStatus
+	JobStatus         *job.JobStatus
+
+	// Pods are names of the resource pods from the last known status and pods which have caused failures
+	Pods []string
+}
+
+func (mt *multitracker) setResourceReady(id ResourceID) {
+	result := mt.results[id]
+	result.State = ResourceReady
+	result.TimeToReady = time.Since(mt.startedAt)
+}
+
+func (mt *multitracker) setResourceTrackerFailed(id ResourceID, err error) {
+	result := mt.results[id]
+	if result.State != "" {
+		return
+	}
+	result.State = ResourceFailed
+	result.FailureReasons = appendElemIfNotExist(result.FailureReasons, err.Error())
+}
+
+func (mt *multitracker) addResourceFailure(id ResourceID, podName, reason string) {
+	result := mt.results[id]
+	result.FailuresCount++
+	result.FailureReasons = appendElemIfNotExist(result.FailureReasons, reason)
+	if podName != "" {
+		result.Pods = appendElemIfNotExist(result.Pods, podName)
+	}
+}
+
+// resourcesResults returns final results of tracked resources, should be called when all trackers are done
+func (mt *multitracker) resourcesResults() []ResourceResult {
+	mt.handlerMux.Lock()
+	defer mt.handlerMux.Unlock()
+
+	results := []ResourceResult{}
+
+	for _, id := range mt.resourcesOrder {
+		result := *mt.results[id]
+
+		if result.State == "" {
+			if state, hasKey := mt.resourcesStates(id.Kind)[id]; hasKey && state.IsFailed {
+				result.State = ResourceFailed
+			} else {
+				result.State = ResourceInterrupted
+			}
+		}
+
+		var podsStatuses map[string]pod.PodStatus
+
+		switch id.Kind {
+		case "po":
+			if status, hasKey := mt.PodsStatuses[id]; hasKey {
+				result.PodStatus = &status
+			}
+			result.Pods = appendElemIfNotExist(result.Pods, id.Name)
+		case "deploy":
+			if status, hasKey := mt.DeploymentsStatuses[id]; hasKey {
+				result.DeploymentStatus = &status
+				podsStatuses = status.Pods
+			}
+		case "sts":
+			if status, hasKey := mt.StatefulSetsStatuses[id]; hasKey {
+				result.StatefulSetStatus = &status
+				podsStatuses = status.Pods
+			}
+		case "ds":
+			if status, hasKey := mt.DaemonSetsStatuses[id]; hasKey {
+				result.DaemonSetStatus = &status
+				podsStatuses = status.Pods
+			}
+		case "job":
+			if status, hasKey := mt.JobsStatuses[id]; hasKey {
+				result.JobStatus = &status
+				podsStatuses = status.Pods
+			}
+		}
+
+		podsNames := []string{}
+		for podName := range podsStatuses {
+			podsNames = append(podsNames, podName)
+		}
+		sort.Strings(podsNames)
+
+		result.Pods = append([]string{}, result.Pods...)
+		for _, podName := range podsNames {
+			result.Pods = appendElemIfNotExist(result.Pods, podName)
+		}
+		result.FailureReasons = append([]string{}, result.FailureReasons...)
+
+		results = append(results, result)
+	}
+
+	return results
+}
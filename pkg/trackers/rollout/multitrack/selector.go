@@ -0,0 +1,174 @@
+package multitrack
+
+import (
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ExpandLabelSelectorSpecs replaces specs with LabelSelector by specs of all resources matching the selector
+// at the moment. Matched resources get options of the selector spec. Resources which already have their own spec
+// are not duplicated. Matched pods managed by a tracked Deployment, StatefulSet, DaemonSet or Job are skipped:
+// they are tracked by the tracker of their controller.
+func ExpandLabelSelectorSpecs(kube kubernetes.Interface, specs MultitrackSpecs) (MultitrackSpecs, error) {
+	res := MultitrackSpecs{}
+	resourceIDs := make(map[ResourceID]bool)
+
+	// Pods are expanded last to know which controllers are tracked
+	for _, group := range []struct {
+		Kind  string
+		Specs []MultitrackSpec
+	}{
+		{"deploy", specs.Deployments},
+		{"sts", specs.StatefulSets},
+		{"ds", specs.DaemonSets},
+		{"job", specs.Jobs},
+		{"po", specs.Pods},
+	} {
+		for _, spec := range group.Specs {
+			if spec.LabelSelector == "" {
+				resourceIDs[ResourceID{Namespace: spec.Namespace, Kind: group.Kind, Name: spec.ResourceName}] = true
+				if err := res.Add(group.Kind, spec); err != nil {
+					return MultitrackSpecs{}, err
+				}
+			}
+		}
+
+		for _, spec := range group.Specs {
+			if spec.LabelSelector == "" {
+				continue
+			}
+
+			objects, err := listResources(kube, group.Kind, spec.Namespace, spec.LabelSelector)
+			if err != nil {
+				return MultitrackSpecs{}, fmt.Errorf("unable to list %s matching selector %q in ns/%s: %s", group.Kind, spec.LabelSelector, spec.Namespace, err)
+			}
+
+			for _, obj := range objects {
+				id := ResourceID{Namespace: spec.Namespace, Kind: group.Kind, Name: obj.GetName()}
+				if resourceIDs[id] {
+					continue
+				}
+
+				if group.Kind == "po" {
+					controllerID, err := podControllerID(kube, obj)
+					if err != nil {
+						return MultitrackSpecs{}, fmt.Errorf("unable to get controller of po/%s in ns/%s: %s", obj.GetName(), spec.Namespace, err)
+					}
+					if resourceIDs[controllerID] {
+						continue
+					}
+				}
+
+				resourceIDs[id] = true
+
+				newSpec := spec
+				newSpec.ResourceName = obj.GetName()
+				newSpec.LabelSelector = ""
+				if err := res.Add(group.Kind, newSpec); err != nil {
+					return MultitrackSpecs{}, err
+				}
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// podControllerID returns id of the Deployment, StatefulSet, DaemonSet or Job managing the pod,
+// empty id is returned for pods without such controller
+func podControllerID(kube kubernetes.Interface, pod metav1.Object) (ResourceID, error) {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return ResourceID{}, nil
+	}
+
+	switch ref.Kind {
+	case "ReplicaSet":
+		rs, err := kube.ExtensionsV1beta1().ReplicaSets(pod.GetNamespace()).Get(ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return ResourceID{}, err
+		}
+		if rsRef := metav1.GetControllerOf(rs); rsRef != nil && rsRef.Kind == "Deployment" {
+			return ResourceID{Namespace: pod.GetNamespace(), Kind: "deploy", Name: rsRef.Name}, nil
+		}
+	case "StatefulSet":
+		return ResourceID{Namespace: pod.GetNamespace(), Kind: "sts", Name: ref.Name}, nil
+	case "DaemonSet":
+		return ResourceID{Namespace: pod.GetNamespace(), Kind: "ds", Name: ref.Name}, nil
+	case "Job":
+		return ResourceID{Namespace: pod.GetNamespace(), Kind: "job", Name: ref.Name}, nil
+	}
+
+	return ResourceID{}, nil
+}
+
+func hasLabelSelectorSpecs(specs MultitrackSpecs) bool {
+	for _, group := range [][]MultitrackSpec{specs.Pods, specs.Deployments, specs.StatefulSets, specs.DaemonSets, specs.Jobs} {
+		for _, spec := range group {
+			if spec.LabelSelector != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// listResources returns resources of the kind matching the label selector sorted by name
+func listResources(kube kubernetes.Interface, kind, namespace, labelSelector string) ([]metav1.Object, error) {
+	options := metav1.ListOptions{LabelSelector: labelSelector}
+	objects := []metav1.Object{}
+
+	switch kind {
+	case "po":
+		list, err := kube.CoreV1().Pods(namespace).List(options)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	case "deploy":
+		list, err := kube.ExtensionsV1beta1().Deployments(namespace).List(options)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	case "sts":
+		list, err := kube.AppsV1().StatefulSets(namespace).List(options)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	case "ds":
+		list, err := kube.ExtensionsV1beta1().DaemonSets(namespace).List(options)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	case "job":
+		list, err := kube.BatchV1().Jobs(namespace).List(options)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	default:
+		return nil, fmt.Errorf("unknown resource kind %q", kind)
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].GetName() < objects[j].GetName()
+	})
+
+	return objects, nil
+}
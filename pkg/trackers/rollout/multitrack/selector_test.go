@@ -0,0 +1,135 @@
+package multitrack
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestExpandLabelSelectorSpecs(t *testing.T) {
+	labels := map[string]string{"app": "myapp"}
+	isController := true
+
+	newPod := func(name string, owner *metav1.OwnerReference) *corev1.Pod {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "myns", Labels: labels}}
+		if owner != nil {
+			owner.Controller = &isController
+			pod.OwnerReferences = []metav1.OwnerReference{*owner}
+		}
+		return pod
+	}
+
+	kube := fake.NewSimpleClientset(
+		&extensions.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "myns", Labels: labels}},
+		&extensions.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name:            "api-5d8f",
+			Namespace:       "myns",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "api", Controller: &isController}},
+		}},
+		&extensions.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "standalone", Namespace: "myns"}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "myns", Labels: map[string]string{"app": "db"}}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "cache", Namespace: "myns", Labels: labels}},
+		newPod("api-5d8f-abcde", &metav1.OwnerReference{Kind: "ReplicaSet", Name: "api-5d8f"}),
+		newPod("standalone-fghij", &metav1.OwnerReference{Kind: "ReplicaSet", Name: "standalone"}),
+		newPod("cache-0", &metav1.OwnerReference{Kind: "StatefulSet", Name: "cache"}),
+		// controller of the pod does not match the selector and is not tracked
+		newPod("db-0", &metav1.OwnerReference{Kind: "StatefulSet", Name: "db"}),
+		newPod("migrate-klmno", &metav1.OwnerReference{Kind: "Job", Name: "migrate"}),
+		newPod("debug", nil),
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "myns", Labels: map[string]string{"app": "other"}}},
+	)
+
+	tests := []struct {
+		name     string
+		specs    MultitrackSpecs
+		expected map[string][]string
+	}{
+		{
+			name: "all kinds",
+			specs: MultitrackSpecs{
+				Pods:         []MultitrackSpec{{LabelSelector: "app=myapp", Namespace: "myns"}},
+				Deployments:  []MultitrackSpec{{LabelSelector: "app=myapp", Namespace: "myns"}},
+				StatefulSets: []MultitrackSpec{{LabelSelector: "app=myapp", Namespace: "myns"}},
+				DaemonSets:   []MultitrackSpec{{LabelSelector: "app=myapp", Namespace: "myns"}},
+				Jobs:         []MultitrackSpec{{LabelSelector: "app=myapp", Namespace: "myns"}},
+			},
+			expected: map[string][]string{
+				"po":     {"db-0", "debug", "migrate-klmno", "standalone-fghij"},
+				"deploy": {"api"},
+				"sts":    {"cache"},
+			},
+		},
+		{
+			name:  "pods only",
+			specs: MultitrackSpecs{Pods: []MultitrackSpec{{LabelSelector: "app=myapp", Namespace: "myns"}}},
+			expected: map[string][]string{
+				"po": {"api-5d8f-abcde", "cache-0", "db-0", "debug", "migrate-klmno", "standalone-fghij"},
+			},
+		},
+		{
+			name: "controller tracked by name",
+			specs: MultitrackSpecs{
+				Pods:         []MultitrackSpec{{LabelSelector: "app=myapp", Namespace: "myns"}},
+				StatefulSets: []MultitrackSpec{{ResourceName: "db", Namespace: "myns"}},
+			},
+			expected: map[string][]string{
+				"po":  {"api-5d8f-abcde", "cache-0", "debug", "migrate-klmno", "standalone-fghij"},
+				"sts": {"db"},
+			},
+		},
+		{
+			name: "resource with own spec is not duplicated",
+			specs: MultitrackSpecs{
+				Pods: []MultitrackSpec{
+					{LabelSelector: "app=myapp", Namespace: "myns"},
+					{ResourceName: "debug", Namespace: "myns"},
+				},
+			},
+			expected: map[string][]string{
+				"po": {"debug", "api-5d8f-abcde", "cache-0", "db-0", "migrate-klmno", "standalone-fghij"},
+			},
+		},
+		{
+			name:     "other namespace",
+			specs:    MultitrackSpecs{Pods: []MultitrackSpec{{LabelSelector: "app=myapp", Namespace: "other"}}},
+			expected: map[string][]string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			specs, err := ExpandLabelSelectorSpecs(kube, test.specs)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			names := make(map[string][]string)
+			for _, group := range []struct {
+				Kind  string
+				Specs []MultitrackSpec
+			}{
+				{"po", specs.Pods},
+				{"deploy", specs.Deployments},
+				{"sts", specs.StatefulSets},
+				{"ds", specs.DaemonSets},
+				{"job", specs.Jobs},
+			} {
+				for _, spec := range group.Specs {
+					if spec.LabelSelector != "" {
+						t.Errorf("%s/%s: label selector is not expanded", group.Kind, spec.ResourceName)
+					}
+					names[group.Kind] = append(names[group.Kind], spec.ResourceName)
+				}
+			}
+
+			if !reflect.DeepEqual(names, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, names)
+			}
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package multitrack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/flant/kubedog/pkg/display"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// multitrackSpecsFile is a serialized form of MultitrackSpecs used in YAML and JSON spec files
+type multitrackSpecsFile struct {
+	Pods         []multitrackSpecFile `json:"pods"`
+	Deployments  []multitrackSpecFile `json:"deployments"`
+	StatefulSets []multitrackSpecFile `json:"statefulSets"`
+	DaemonSets   []multitrackSpecFile `json:"daemonSets"`
+	Jobs         []multitrackSpecFile `json:"jobs"`
+}
+
+type multitrackSpecFile struct {
+	ResourceName  string `json:"resourceName"`
+	LabelSelector string `json:"labelSelector"`
+	Namespace     string `json:"namespace"`
+
+	FailMode                FailMode `json:"failMode"`
+	AllowFailuresCount      *int     `json:"allowFailuresCount"`
+	FailureThresholdSeconds *int     `json:"failureThresholdSeconds"`
+
+	LogWatchRegex                string            `json:"logWatchRegex"`
+	LogWatchRegexByContainerName map[string]string `json:"logWatchRegexByContainerName"`
+	LogMinLevel                  string            `json:"logMinLevel"`
+
+	FailOnLogRegex                   string            `json:"failOnLogRegex"`
+	FailOnLogRegexByContainerName    map[string]string `json:"failOnLogRegexByContainerName"`
+	SucceedOnLogRegex                string            `json:"succeedOnLogRegex"`
+	SucceedOnLogRegexByContainerName map[string]string `json:"succeedOnLogRegexByContainerName"`
+	ShowLogsUntil                    DeployCondition   `json:"showLogsUntil"`
+	SkipLogsForContainers            []string          `json:"skipLogsForContainers"`
+	ShowLogsOnlyForContainers        []string          `json:"showLogsOnlyForContainers"`
+
+	TimeoutSeconds              int `json:"timeoutSeconds"`
+	NoProgressTimeoutSeconds    int `json:"noProgressTimeoutSeconds"`
+	UnschedulableTimeoutSeconds int `json:"unschedulableTimeoutSeconds"`
+
+	MaxRestarts *int `json:"maxRestarts"`
+
+	DependsOn                    []string `json:"dependsOn"`
+	TrackBeforeDependenciesReady bool     `json:"trackBeforeDependenciesReady"`
+}
+
+// ReadMultitrackSpecs decodes MultitrackSpecs from a YAML or JSON document.
+// Resources without namespace are placed into defaultNamespace.
+func ReadMultitrackSpecs(r io.Reader, defaultNamespace string) (MultitrackSpecs, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return MultitrackSpecs{}, err
+	}
+
+	jsonData, err := yaml.ToJSON(data)
+	if err != nil {
+		return MultitrackSpecs{}, fmt.Errorf("unable to parse specs: %s", err)
+	}
+
+	var specsFile multitrackSpecsFile
+
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&specsFile); err != nil {
+		return MultitrackSpecs{}, fmt.Errorf("unable to decode specs: %s", err)
+	}
+
+	specs := MultitrackSpecs{}
+
+	for _, group := range []struct {
+		Field string
+		From  []multitrackSpecFile
+		To    *[]MultitrackSpec
+	}{
+		{"pods", specsFile.Pods, &specs.Pods},
+		{"deployments", specsFile.Deployments, &specs.Deployments},
+		{"statefulSets", specsFile.StatefulSets, &specs.StatefulSets},
+		{"daemonSets", specsFile.DaemonSets, &specs.DaemonSets},
+		{"jobs", specsFile.Jobs, &specs.Jobs},
+	} {
+		for i, specFile := range group.From {
+			spec, err := specFile.toMultitrackSpec(defaultNamespace)
+			if err != nil {
+				return MultitrackSpecs{}, fmt.Errorf("%s[%d]: %s", group.Field, i, err)
+			}
+
+			if err := ValidateSpec(spec); err != nil {
+				return MultitrackSpecs{}, fmt.Errorf("%s[%d]: %s", group.Field, i, err)
+			}
+
+			*group.To = append(*group.To, spec)
+		}
+	}
+
+	if err := ValidateSpecs(specs); err != nil {
+		return MultitrackSpecs{}, err
+	}
+
+	return specs, nil
+}
+
+func (specFile multitrackSpecFile) toMultitrackSpec(defaultNamespace string) (MultitrackSpec, error) {
+	spec := MultitrackSpec{
+		ResourceName:              specFile.ResourceName,
+		LabelSelector:             specFile.LabelSelector,
+		Namespace:                 specFile.Namespace,
+		FailMode:                  specFile.FailMode,
+		AllowFailuresCount:        specFile.AllowFailuresCount,
+		FailureThresholdSeconds:   specFile.FailureThresholdSeconds,
+		ShowLogsUntil:             specFile.ShowLogsUntil,
+		SkipLogsForContainers:     specFile.SkipLogsForContainers,
+		ShowLogsOnlyForContainers: specFile.ShowLogsOnlyForContainers,
+
+		TimeoutSeconds:              specFile.TimeoutSeconds,
+		NoProgressTimeoutSeconds:    specFile.NoProgressTimeoutSeconds,
+		UnschedulableTimeoutSeconds: specFile.UnschedulableTimeoutSeconds,
+		MaxRestarts:                 specFile.MaxRestarts,
+
+		DependsOn:                    specFile.DependsOn,
+		TrackBeforeDependenciesReady: specFile.TrackBeforeDependenciesReady,
+	}
+
+	if spec.Namespace == "" {
+		spec.Namespace = defaultNamespace
+	}
+
+	for _, field := range []struct {
+		Name  string
+		Expr  string
+		Regex **regexp.Regexp
+	}{
+		{"logWatchRegex", specFile.LogWatchRegex, &spec.LogWatchRegex},
+		{"failOnLogRegex", specFile.FailOnLogRegex, &spec.FailOnLogRegex},
+		{"succeedOnLogRegex", specFile.SucceedOnLogRegex, &spec.SucceedOnLogRegex},
+	} {
+		if field.Expr == "" {
+			continue
+		}
+
+		regex, err := regexp.Compile(field.Expr)
+		if err != nil {
+			return MultitrackSpec{}, fmt.Errorf("bad %s %q: %s", field.Name, field.Expr, err)
+		}
+		*field.Regex = regex
+	}
+
+	if specFile.LogMinLevel != "" {
+		logMinLevel, err := display.ParseLogLevel(specFile.LogMinLevel)
+		if err != nil {
+			return MultitrackSpec{}, fmt.Errorf("bad logMinLevel: %s", err)
+		}
+		spec.LogMinLevel = logMinLevel
+	}
+
+	for _, field := range []struct {
+		Name    string
+		Exprs   map[string]string
+		Regexes *map[string]*regexp.Regexp
+	}{
+		{"logWatchRegexByContainerName", specFile.LogWatchRegexByContainerName, &spec.LogWatchRegexByContainerName},
+		{"failOnLogRegexByContainerName", specFile.FailOnLogRegexByContainerName, &spec.FailOnLogRegexByContainerName},
+		{"succeedOnLogRegexByContainerName", specFile.SucceedOnLogRegexByContainerName, &spec.SucceedOnLogRegexByContainerName},
+	} {
+		if len(field.Exprs) == 0 {
+			continue
+		}
+
+		*field.Regexes = make(map[string]*regexp.Regexp)
+		for containerName, expr := range field.Exprs {
+			regex, err := regexp.Compile(expr)
+			if err != nil {
+				return MultitrackSpec{}, fmt.Errorf("bad %s[%s] %q: %s", field.Name, containerName, expr, err)
+			}
+			(*field.Regexes)[containerName] = regex
+		}
+	}
+
+	return spec, nil
+}
+
+// ValidateSpecs checks all specs with ValidateSpec, rejects duplicate specs of the same resource,
+// dependencies on resources which are not tracked and dependency cycles.
+// Dependencies on resources which are not tracked are allowed when specs have LabelSelector.
+func ValidateSpecs(specs MultitrackSpecs) error {
+	resourceIDs := make(map[ResourceID]bool)
+	dependencies := make(map[ResourceID][]ResourceID)
+	order := []ResourceID{}
+
+	for _, group := range []struct {
+		Kind  string
+		Specs []MultitrackSpec
+	}{
+		{"po", specs.Pods},
+		{"deploy", specs.Deployments},
+		{"sts", specs.StatefulSets},
+		{"ds", specs.DaemonSets},
+		{"job", specs.Jobs},
+	} {
+		for _, spec := range group.Specs {
+			if err := ValidateSpec(spec); err != nil {
+				return err
+			}
+
+			// Resources matching the selector are known only after ExpandLabelSelectorSpecs
+			if spec.LabelSelector != "" {
+				continue
+			}
+
+			id := ResourceID{Namespace: spec.Namespace, Kind: group.Kind, Name: spec.ResourceName}
+			if resourceIDs[id] {
+				return fmt.Errorf("%s: duplicate spec", id)
+			}
+			resourceIDs[id] = true
+
+			dependencies[id] = specDependencies(spec)
+			order = append(order, id)
+		}
+	}
+
+	return validateDependencies(dependencies, order, hasLabelSelectorSpecs(specs))
+}
+
+// ValidateSpec checks that spec fields have allowed values
+func ValidateSpec(spec MultitrackSpec) error {
+	if spec.ResourceName == "" && spec.LabelSelector == "" {
+		return fmt.Errorf("resourceName or labelSelector is required")
+	}
+
+	name := spec.ResourceName
+	if spec.LabelSelector != "" {
+		if spec.ResourceName != "" {
+			return fmt.Errorf("%s: resourceName and labelSelector cannot be used together", name)
+		}
+
+		name = fmt.Sprintf("selector %q", spec.LabelSelector)
+		if _, err := labels.Parse(spec.LabelSelector); err != nil {
+			return fmt.Errorf("%s: bad labelSelector: %s", name, err)
+		}
+	}
+
+	switch spec.FailMode {
+	case "", IgnoreAndContinueDeployProcess, FailWholeDeployProcessImmediately, HopeUntilEndOfDeployProcess:
+	default:
+		return fmt.Errorf("%s: bad failMode %q, expected one of: %s", name, spec.FailMode, strings.Join([]string{
+			string(IgnoreAndContinueDeployProcess),
+			string(FailWholeDeployProcessImmediately),
+			string(HopeUntilEndOfDeployProcess),
+		}, ", "))
+	}
+
+	if spec.AllowFailuresCount != nil && *spec.AllowFailuresCount < 0 {
+		return fmt.Errorf("%s: allowFailuresCount should not be negative, got %d", name, *spec.AllowFailuresCount)
+	}
+
+	if spec.FailureThresholdSeconds != nil && *spec.FailureThresholdSeconds < 0 {
+		return fmt.Errorf("%s: failureThresholdSeconds should not be negative, got %d", name, *spec.FailureThresholdSeconds)
+	}
+
+	if spec.TimeoutSeconds < 0 {
+		return fmt.Errorf("%s: timeoutSeconds should not be negative, got %d", name, spec.TimeoutSeconds)
+	}
+
+	if spec.NoProgressTimeoutSeconds < 0 {
+		return fmt.Errorf("%s: noProgressTimeoutSeconds should not be negative, got %d", name, spec.NoProgressTimeoutSeconds)
+	}
+
+	if spec.UnschedulableTimeoutSeconds < 0 {
+		return fmt.Errorf("%s: unschedulableTimeoutSeconds should not be negative, got %d", name, spec.UnschedulableTimeoutSeconds)
+	}
+
+	if spec.MaxRestarts != nil && *spec.MaxRestarts < 0 {
+		return fmt.Errorf("%s: maxRestarts should not be negative, got %d", name, *spec.MaxRestarts)
+	}
+
+	switch spec.ShowLogsUntil {
+	case "", ControllerIsReady, PodIsReady, EndOfDeploy:
+	default:
+		return fmt.Errorf("%s: bad showLogsUntil %q, expected one of: %s", name, spec.ShowLogsUntil, strings.Join([]string{
+			string(ControllerIsReady),
+			string(PodIsReady),
+			string(EndOfDeploy),
+		}, ", "))
+	}
+
+	if len(spec.SkipLogsForContainers) > 0 && len(spec.ShowLogsOnlyForContainers) > 0 {
+		return fmt.Errorf("%s: skipLogsForContainers and showLogsOnlyForContainers cannot be used together", name)
+	}
+
+	for _, dependency := range spec.DependsOn {
+		if _, _, err := ParseResourceKindName(dependency); err != nil {
+			return fmt.Errorf("%s: bad dependsOn: %s", name, err)
+		}
+	}
+
+	return nil
+}
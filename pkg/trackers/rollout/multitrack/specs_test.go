@@ -0,0 +1,176 @@
+package multitrack
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadMultitrackSpecs(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		// err is a substring of the expected error, no error is expected when empty
+		err    string
+		verify func(t *testing.T, specs MultitrackSpecs)
+	}{
+		{
+			name: "yaml with default namespace",
+			document: `
+deployments:
+- resourceName: api
+  failMode: HopeUntilEndOfDeployProcess
+  allowFailuresCount: 3
+  logWatchRegex: "ERROR"
+jobs:
+- resourceName: migrate
+  namespace: db
+`,
+			verify: func(t *testing.T, specs MultitrackSpecs) {
+				if len(specs.Deployments) != 1 || len(specs.Jobs) != 1 {
+					t.Fatalf("expected 1 deployment and 1 job, got %#v", specs)
+				}
+
+				deploy := specs.Deployments[0]
+				if deploy.ResourceName != "api" || deploy.Namespace != "default" {
+					t.Errorf("expected deploy/api in ns/default, got %s in ns/%s", deploy.ResourceName, deploy.Namespace)
+				}
+				if deploy.FailMode != HopeUntilEndOfDeployProcess {
+					t.Errorf("expected failMode %s, got %s", HopeUntilEndOfDeployProcess, deploy.FailMode)
+				}
+				if deploy.AllowFailuresCount == nil || *deploy.AllowFailuresCount != 3 {
+					t.Errorf("expected allowFailuresCount 3, got %v", deploy.AllowFailuresCount)
+				}
+				if deploy.LogWatchRegex == nil || !deploy.LogWatchRegex.MatchString("some ERROR") {
+					t.Errorf("expected logWatchRegex ERROR, got %v", deploy.LogWatchRegex)
+				}
+
+				if specs.Jobs[0].Namespace != "db" {
+					t.Errorf("expected job in ns/db, got ns/%s", specs.Jobs[0].Namespace)
+				}
+			},
+		},
+		{
+			name:     "json",
+			document: `{"pods": [{"resourceName": "mypod", "failOnLogRegexByContainerName": {"main": "FATAL"}}]}`,
+			verify: func(t *testing.T, specs MultitrackSpecs) {
+				if len(specs.Pods) != 1 {
+					t.Fatalf("expected 1 pod, got %#v", specs)
+				}
+				if regex := specs.Pods[0].FailOnLogRegexByContainerName["main"]; regex == nil || !regex.MatchString("FATAL error") {
+					t.Errorf("expected failOnLogRegexByContainerName[main] FATAL, got %v", regex)
+				}
+			},
+		},
+		{
+			name:     "unknown field",
+			document: "deployments:\n- resourceName: api\n  allowFailures: 3\n",
+			err:      "unknown field",
+		},
+		{
+			name:     "bad regex",
+			document: "deployments:\n- resourceName: api\n  logWatchRegex: \"(\"\n",
+			err:      "deployments[0]: bad logWatchRegex",
+		},
+		{
+			name:     "bad log level",
+			document: "deployments:\n- resourceName: api\n  logMinLevel: loud\n",
+			err:      "deployments[0]: bad logMinLevel",
+		},
+		{
+			name:     "invalid spec",
+			document: "statefulSets:\n- resourceName: db\n- namespace: other\n",
+			err:      "statefulSets[1]: resourceName or labelSelector is required",
+		},
+		{
+			name:     "duplicate spec",
+			document: "jobs:\n- resourceName: migrate\n- resourceName: migrate\n",
+			err:      "duplicate spec",
+		},
+		{
+			name:     "same name in different namespaces",
+			document: "jobs:\n- resourceName: migrate\n- resourceName: migrate\n  namespace: other\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			specs, err := ReadMultitrackSpecs(strings.NewReader(test.document), "default")
+			checkError(t, err, test.err)
+			if err == nil && test.verify != nil {
+				test.verify(t, specs)
+			}
+		})
+	}
+}
+
+func TestValidateSpec(t *testing.T) {
+	negative := -1
+	zero := 0
+
+	tests := []struct {
+		name string
+		spec MultitrackSpec
+		err  string
+	}{
+		{name: "minimal", spec: MultitrackSpec{ResourceName: "api"}},
+		{name: "label selector", spec: MultitrackSpec{LabelSelector: "app=api"}},
+		{name: "no name", spec: MultitrackSpec{}, err: "resourceName or labelSelector is required"},
+		{name: "name and label selector", spec: MultitrackSpec{ResourceName: "api", LabelSelector: "app=api"}, err: "cannot be used together"},
+		{name: "bad label selector", spec: MultitrackSpec{LabelSelector: "app in ("}, err: "bad labelSelector"},
+		{name: "bad fail mode", spec: MultitrackSpec{ResourceName: "api", FailMode: "Retry"}, err: "bad failMode \"Retry\""},
+		{name: "zero allow failures count", spec: MultitrackSpec{ResourceName: "api", AllowFailuresCount: &zero}},
+		{name: "negative allow failures count", spec: MultitrackSpec{ResourceName: "api", AllowFailuresCount: &negative}, err: "allowFailuresCount should not be negative"},
+		{name: "negative failure threshold", spec: MultitrackSpec{ResourceName: "api", FailureThresholdSeconds: &negative}, err: "failureThresholdSeconds should not be negative"},
+		{name: "negative timeout", spec: MultitrackSpec{ResourceName: "api", TimeoutSeconds: -1}, err: "timeoutSeconds should not be negative"},
+		{name: "negative no progress timeout", spec: MultitrackSpec{ResourceName: "api", NoProgressTimeoutSeconds: -1}, err: "noProgressTimeoutSeconds should not be negative"},
+		{name: "negative unschedulable timeout", spec: MultitrackSpec{ResourceName: "api", UnschedulableTimeoutSeconds: -1}, err: "unschedulableTimeoutSeconds should not be negative"},
+		{name: "zero max restarts", spec: MultitrackSpec{ResourceName: "api", MaxRestarts: &zero}},
+		{name: "negative max restarts", spec: MultitrackSpec{ResourceName: "api", MaxRestarts: &negative}, err: "maxRestarts should not be negative"},
+		{name: "bad show logs until", spec: MultitrackSpec{ResourceName: "api", ShowLogsUntil: "Never"}, err: "bad showLogsUntil"},
+		{
+			name: "skip and show only logs",
+			spec: MultitrackSpec{ResourceName: "api", SkipLogsForContainers: []string{"a"}, ShowLogsOnlyForContainers: []string{"b"}},
+			err:  "cannot be used together",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checkError(t, ValidateSpec(test.spec), test.err)
+		})
+	}
+}
+
+// checkError checks that err contains expected substring, or that there is no error when expected is empty
+func TestMultitrackSpecsAdd(t *testing.T) {
+	specs := MultitrackSpecs{}
+
+	for _, kind := range []string{"po", "deploy", "sts", "ds", "job"} {
+		checkError(t, specs.Add(kind, MultitrackSpec{ResourceName: "myresource"}), "")
+	}
+	checkError(t, specs.Add("deployment", MultitrackSpec{ResourceName: "myresource"}), `unknown resource kind "deployment"`)
+
+	for _, group := range [][]MultitrackSpec{specs.Pods, specs.Deployments, specs.StatefulSets, specs.DaemonSets, specs.Jobs} {
+		if len(group) != 1 {
+			t.Errorf("expected one spec of every kind, got %#v", specs)
+		}
+	}
+}
+
+func checkError(t *testing.T, err error, expected string) {
+	t.Helper()
+
+	if expected == "" {
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return
+	}
+
+	if err == nil {
+		t.Fatalf("expected error %q, got nil", expected)
+	}
+	if !strings.Contains(err.Error(), expected) {
+		t.Fatalf("expected error %q, got %q", expected, err)
+	}
+}
@@ -11,107 +11,136 @@ import (
 
 func (mt *multitracker) TrackStatefulSet(kube kubernetes.Interface, spec MultitrackSpec, opts MultitrackOptions) error {
 	feed := statefulset.NewFeed()
+	id := ResourceID{Namespace: spec.Namespace, Kind: "sts", Name: spec.ResourceName}
 
 	feed.OnAdded(func(ready bool) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.statefulsetAdded(spec, feed, ready)
+		if err := mt.applyObjectAnnotations(id, &spec, feed.GetStatus().Annotations); err != nil {
+			return err
+		}
+		return mt.statefulsetAdded(id, spec, feed, ready)
 	})
 	feed.OnReady(func() error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.statefulsetReady(spec, feed)
+		return mt.statefulsetReady(id, spec, feed)
 	})
 	feed.OnFailed(func(reason string) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.statefulsetFailed(spec, feed, reason)
+		return mt.statefulsetFailed(id, spec, feed, reason)
 	})
 	feed.OnEventMsg(func(msg string) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.statefulsetEventMsg(spec, feed, msg)
+		return mt.statefulsetEventMsg(id, spec, feed, msg)
 	})
 	feed.OnAddedReplicaSet(func(rs replicaset.ReplicaSet) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.statefulsetAddedReplicaSet(spec, feed, rs)
+		return mt.statefulsetAddedReplicaSet(id, spec, feed, rs)
 	})
 	feed.OnAddedPod(func(pod replicaset.ReplicaSetPod) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.statefulsetAddedPod(spec, feed, pod)
+		return mt.statefulsetAddedPod(id, spec, feed, pod)
 	})
 	feed.OnPodError(func(podError replicaset.ReplicaSetPodError) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.statefulsetPodError(spec, feed, podError)
+		return mt.statefulsetPodError(id, spec, feed, podError)
 	})
 	feed.OnPodLogChunk(func(chunk *replicaset.ReplicaSetPodLogChunk) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.statefulsetPodLogChunk(spec, feed, chunk)
+		return mt.statefulsetPodLogChunk(id, spec, feed, chunk)
+	})
+	feed.OnPodContainerTermination(func(termination replicaset.ReplicaSetPodContainerTermination) error {
+		mt.handlerMux.Lock()
+		defer mt.handlerMux.Unlock()
+		return mt.statefulsetPodContainerTermination(id, spec, feed, termination)
+	})
+	feed.OnPodUnschedulable(func(failure replicaset.ReplicaSetPodSchedulingFailure) error {
+		mt.handlerMux.Lock()
+		defer mt.handlerMux.Unlock()
+		return mt.statefulsetPodUnschedulable(id, spec, feed, failure)
+	})
+	feed.OnPodContainerRestart(func(restart replicaset.ReplicaSetPodContainerRestart) error {
+		mt.handlerMux.Lock()
+		defer mt.handlerMux.Unlock()
+		return mt.statefulsetPodContainerRestart(id, spec, feed, restart)
 	})
 	feed.OnStatusReport(func(status statefulset.StatefulSetStatus) error {
 		mt.handlerMux.Lock()
 		defer mt.handlerMux.Unlock()
-		return mt.statefulsetStatusReport(spec, feed, status)
+		return mt.statefulsetStatusReport(id, spec, feed, status)
 	})
 
 	return feed.Track(spec.ResourceName, spec.Namespace, kube, opts.Options)
 }
 
-func (mt *multitracker) statefulsetAdded(spec MultitrackSpec, feed statefulset.Feed, ready bool) error {
+func (mt *multitracker) statefulsetAdded(id ResourceID, spec MultitrackSpec, feed statefulset.Feed, ready bool) error {
 	if debug() {
 		fmt.Printf("-- statefulsetAdded %#v %#v\n", spec, ready)
 	}
 
+	mt.StatefulSetsSpecs[id] = spec
+
 	if ready {
-		mt.StatefulSetsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.StatefulSetsStatuses[id] = feed.GetStatus()
 
-		display.OutF("# sts/%s appears to be READY\n", spec.ResourceName)
+		mt.printer.Message(mt.resourceEvent(id, display.ReadyEvent), fmt.Sprintf("# %s appears to be READY", mt.resourceName(id)))
 
-		return mt.handleResourceReadyCondition(mt.TrackingStatefulSets, spec)
+		return mt.handleResourceReadyCondition(mt.TrackingStatefulSets, id, spec)
 	}
 
-	display.OutF("# sts/%s added\n", spec.ResourceName)
+	mt.printer.Message(mt.resourceEvent(id, display.AddedEvent), fmt.Sprintf("# %s added", mt.resourceName(id)))
 
 	return nil
 }
 
-func (mt *multitracker) statefulsetReady(spec MultitrackSpec, feed statefulset.Feed) error {
+func (mt *multitracker) statefulsetReady(id ResourceID, spec MultitrackSpec, feed statefulset.Feed) error {
 	if debug() {
 		fmt.Printf("-- statefulsetReady %#v\n", spec)
 	}
 
-	mt.StatefulSetsStatuses[spec.ResourceName] = feed.GetStatus()
+	if !mt.isResourceTracked(mt.TrackingStatefulSets, id) {
+		return nil
+	}
+
+	mt.StatefulSetsStatuses[id] = feed.GetStatus()
 
-	display.OutF("# sts/%s become READY\n", spec.ResourceName)
+	mt.printer.Message(mt.resourceEvent(id, display.ReadyEvent), fmt.Sprintf("# %s become READY", mt.resourceName(id)))
 
-	return mt.handleResourceReadyCondition(mt.TrackingStatefulSets, spec)
+	return mt.handleResourceReadyCondition(mt.TrackingStatefulSets, id, spec)
 }
 
-func (mt *multitracker) statefulsetFailed(spec MultitrackSpec, feed statefulset.Feed, reason string) error {
+func (mt *multitracker) statefulsetFailed(id ResourceID, spec MultitrackSpec, feed statefulset.Feed, reason string) error {
 	if debug() {
 		fmt.Printf("-- statefulsetFailed %#v %#v\n", spec, reason)
 	}
 
-	display.OutF("# sts/%s FAIL: %s\n", spec.ResourceName, reason)
+	if !mt.isResourceTracked(mt.TrackingStatefulSets, id) {
+		return nil
+	}
+
+	mt.printer.Message(mt.resourceEvent(id, display.FailedEvent).WithMessage(reason), fmt.Sprintf("# %s FAIL: %s", mt.resourceName(id), reason))
 
-	return mt.handleResourceFailure(mt.TrackingStatefulSets, spec, reason)
+	return mt.handleResourceFailure(mt.TrackingStatefulSets, id, spec, "", reason)
 }
 
-func (mt *multitracker) statefulsetEventMsg(spec MultitrackSpec, feed statefulset.Feed, msg string) error {
+func (mt *multitracker) statefulsetEventMsg(id ResourceID, spec MultitrackSpec, feed statefulset.Feed, msg string) error {
 	if debug() {
 		fmt.Printf("-- statefulsetEventMsg %#v %#v\n", spec, msg)
 	}
 
-	display.OutF("# sts/%s event: %s\n", spec.ResourceName, msg)
+	mt.printer.Message(mt.resourceEvent(id, display.EventMsgEvent).WithMessage(msg), fmt.Sprintf("# %s event: %s", mt.resourceName(id), msg))
 
 	return nil
 }
 
-func (mt *multitracker) statefulsetAddedReplicaSet(spec MultitrackSpec, feed statefulset.Feed, rs replicaset.ReplicaSet) error {
+func (mt *multitracker) statefulsetAddedReplicaSet(id ResourceID, spec MultitrackSpec, feed statefulset.Feed, rs replicaset.ReplicaSet) error {
 	if debug() {
 		fmt.Printf("-- statefulsetAddedReplicaSet %#v %#v\n", spec, rs)
 	}
@@ -119,12 +148,12 @@ func (mt *multitracker) statefulsetAddedReplicaSet(spec MultitrackSpec, feed sta
 	if !rs.IsNew {
 		return nil
 	}
-	display.OutF("# sts/%s rs/%s added\n", spec.ResourceName, rs.Name)
+	mt.printer.Message(mt.resourceEvent(id, display.AddedReplicaSetEvent).WithReplicaSet(rs.Name), fmt.Sprintf("# %s rs/%s added", mt.resourceName(id), rs.Name))
 
 	return nil
 }
 
-func (mt *multitracker) statefulsetAddedPod(spec MultitrackSpec, feed statefulset.Feed, pod replicaset.ReplicaSetPod) error {
+func (mt *multitracker) statefulsetAddedPod(id ResourceID, spec MultitrackSpec, feed statefulset.Feed, pod replicaset.ReplicaSetPod) error {
 	if debug() {
 		fmt.Printf("-- statefulsetAddedPod %#v %#v\n", spec, pod)
 	}
@@ -132,28 +161,33 @@ func (mt *multitracker) statefulsetAddedPod(spec MultitrackSpec, feed statefulse
 	if !pod.ReplicaSet.IsNew {
 		return nil
 	}
-	display.OutF("# sts/%s po/%s added\n", spec.ResourceName, pod.Name)
+	mt.printer.Message(mt.resourceEvent(id, display.AddedPodEvent).WithPod(pod.Name, ""), fmt.Sprintf("# %s po/%s added", mt.resourceName(id), pod.Name))
 
 	return nil
 }
 
-func (mt *multitracker) statefulsetPodError(spec MultitrackSpec, feed statefulset.Feed, podError replicaset.ReplicaSetPodError) error {
+func (mt *multitracker) statefulsetPodError(id ResourceID, spec MultitrackSpec, feed statefulset.Feed, podError replicaset.ReplicaSetPodError) error {
 	if debug() {
 		fmt.Printf("-- statefulsetPodError %#v %#v\n", spec, podError)
 	}
 
+	if !mt.isResourceTracked(mt.TrackingStatefulSets, id) {
+		return nil
+	}
+
 	if !podError.ReplicaSet.IsNew {
 		return nil
 	}
 
 	reason := fmt.Sprintf("po/%s %s error: %s", podError.PodName, podError.ContainerName, podError.Message)
 
-	display.OutF("# sts/%s %s\n", spec.ResourceName, reason)
+	event := mt.resourceEvent(id, display.PodErrorEvent).WithPod(podError.PodName, podError.ContainerName).WithMessage(podError.Message)
+	mt.printer.Message(event, fmt.Sprintf("# %s %s", mt.resourceName(id), reason))
 
-	return mt.handleResourceFailure(mt.TrackingStatefulSets, spec, reason)
+	return mt.handleResourceFailure(mt.TrackingStatefulSets, id, spec, podError.PodName, reason)
 }
 
-func (mt *multitracker) statefulsetPodLogChunk(spec MultitrackSpec, feed statefulset.Feed, chunk *replicaset.ReplicaSetPodLogChunk) error {
+func (mt *multitracker) statefulsetPodLogChunk(id ResourceID, spec MultitrackSpec, feed statefulset.Feed, chunk *replicaset.ReplicaSetPodLogChunk) error {
 	if debug() {
 		fmt.Printf("-- statefulsetPodLogChunk %#v %#v\n", spec, chunk)
 	}
@@ -162,18 +196,59 @@ func (mt *multitracker) statefulsetPodLogChunk(spec MultitrackSpec, feed statefu
 		return nil
 	}
 
-	header := fmt.Sprintf("sts/%s %s", spec.ResourceName, podContainerLogChunkHeader(chunk.PodName, chunk.ContainerLogChunk))
-	displayContainerLogChunk(header, spec, chunk.ContainerLogChunk)
+	header := fmt.Sprintf("%s %s", mt.resourceName(id), podContainerLogChunkHeader(chunk.PodName, chunk.ContainerLogChunk))
+	return mt.handleContainerLogChunk(mt.TrackingStatefulSets, id, spec, header, chunk.PodName, chunk.ContainerLogChunk, mt.StatefulSetsStatuses[id].Pods[chunk.PodName])
+}
+
+func (mt *multitracker) statefulsetPodContainerTermination(id ResourceID, spec MultitrackSpec, feed statefulset.Feed, termination replicaset.ReplicaSetPodContainerTermination) error {
+	if debug() {
+		fmt.Printf("-- statefulsetPodContainerTermination %#v %#v\n", spec, termination)
+	}
+
+	if !mt.isResourceTracked(mt.TrackingStatefulSets, id) {
+		return nil
+	}
+
+	event := mt.resourceEvent(id, display.ContainerTerminatedEvent).WithPod(termination.PodName, termination.ContainerName)
+	header := fmt.Sprintf("%s po/%s %s (terminated)", mt.resourceName(id), termination.PodName, termination.ContainerName)
+	return mt.handleContainerTermination(mt.TrackingStatefulSets, id, event, header, termination.PodContainerTermination)
+}
+
+func (mt *multitracker) statefulsetPodUnschedulable(id ResourceID, spec MultitrackSpec, feed statefulset.Feed, failure replicaset.ReplicaSetPodSchedulingFailure) error {
+	if debug() {
+		fmt.Printf("-- statefulsetPodUnschedulable %#v %#v\n", spec, failure)
+	}
+
+	if !mt.isResourceTracked(mt.TrackingStatefulSets, id) {
+		return nil
+	}
+
+	mt.handlePodUnschedulable(id, mt.resourceEvent(id, display.UnschedulableEvent), failure.PodName, failure.PodSchedulingFailure.SchedulingFailure)
 
 	return nil
 }
 
-func (mt *multitracker) statefulsetStatusReport(spec MultitrackSpec, feed statefulset.Feed, status statefulset.StatefulSetStatus) error {
+func (mt *multitracker) statefulsetPodContainerRestart(id ResourceID, spec MultitrackSpec, feed statefulset.Feed, restart replicaset.ReplicaSetPodContainerRestart) error {
+	if debug() {
+		fmt.Printf("-- statefulsetPodContainerRestart %#v %#v\n", spec, restart)
+	}
+
+	if !mt.isResourceTracked(mt.TrackingStatefulSets, id) {
+		return nil
+	}
+
+	event := mt.resourceEvent(id, display.ContainerRestartedEvent).WithPod(restart.PodName, restart.ContainerName)
+	return mt.handleContainerRestart(mt.TrackingStatefulSets, id, spec, event, restart.PodContainerRestart)
+}
+
+func (mt *multitracker) statefulsetStatusReport(id ResourceID, spec MultitrackSpec, feed statefulset.Feed, status statefulset.StatefulSetStatus) error {
 	if debug() {
 		fmt.Printf("-- statefulsetStatusReport %#v %#v\n", spec, status)
 	}
 
-	mt.StatefulSetsStatuses[spec.ResourceName] = status
+	mt.StatefulSetsStatuses[id] = status
+	mt.handleResourceProgress(id)
+	mt.printer.ResourceStatus(mt.resourceEvent(id, display.StatusReportEvent).WithStatus(status))
 
-	return nil
+	return mt.handleResourcePodsStatuses(mt.TrackingStatefulSets, id, spec, status.Pods)
 }
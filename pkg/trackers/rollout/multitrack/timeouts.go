@@ -0,0 +1,207 @@
+package multitrack
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/flant/kubedog/pkg/display"
+	"github.com/flant/kubedog/pkg/tracker"
+	"github.com/flant/kubedog/pkg/tracker/pod"
+)
+
+// resourceTimeouts holds times used to check TimeoutSeconds and NoProgressTimeoutSeconds of the resource
+type resourceTimeouts struct {
+	TrackingStartedAt time.Time
+	LastProgressAt    time.Time
+	LastProgress      string
+}
+
+// handleResourceProgress updates the time of the last progress when the rollout progress of the resource
+// from the last status has changed
+func (mt *multitracker) handleResourceProgress(id ResourceID) {
+	timeouts, hasKey := mt.resourcesTimeouts[id]
+	if !hasKey {
+		return
+	}
+
+	progress := mt.resourceProgress(id)
+	if progress != timeouts.LastProgress {
+		timeouts.LastProgress = progress
+		timeouts.LastProgressAt = time.Now()
+	}
+}
+
+// resourceProgress returns counters of the resource rollout from the last status,
+// changes of pods restarts and other status fields are not a progress
+func (mt *multitracker) resourceProgress(id ResourceID) string {
+	switch id.Kind {
+	case "po":
+		status := mt.PodsStatuses[id]
+		readyContainers := 0
+		for _, containerStatus := range status.ContainerStatuses {
+			if containerStatus.Ready {
+				readyContainers++
+			}
+		}
+		terminatedInitContainers := 0
+		for _, containerStatus := range status.InitContainerStatuses {
+			if containerStatus.State.Terminated != nil {
+				terminatedInitContainers++
+			}
+		}
+		return fmt.Sprintf("%s %d %d", status.Phase, terminatedInitContainers, readyContainers)
+	case "deploy":
+		status := mt.DeploymentsStatuses[id]
+		return fmt.Sprintf("%d %d %d", status.UpdatedReplicas, status.ReadyReplicas, status.AvailableReplicas)
+	case "sts":
+		status := mt.StatefulSetsStatuses[id]
+		return fmt.Sprintf("%d %d %d", status.UpdatedReplicas, status.ReadyReplicas, status.CurrentReplicas)
+	case "ds":
+		status := mt.DaemonSetsStatuses[id]
+		return fmt.Sprintf("%d %d %d", status.UpdatedNumberScheduled, status.NumberReady, status.NumberAvailable)
+	case "job":
+		status := mt.JobsStatuses[id]
+		return fmt.Sprintf("%d %d", status.Active, status.Succeeded)
+	default:
+		panic(fmt.Sprintf("unknown resource kind %s", id.Kind))
+	}
+}
+
+// resourceStatusSummary returns one line summary of the last status of the resource for failure reasons
+func (mt *multitracker) resourceStatusSummary(id ResourceID) string {
+	switch id.Kind {
+	case "po":
+		if status, hasKey := mt.PodsStatuses[id]; hasKey {
+			return fmt.Sprintf("Phase:%s", status.Phase)
+		}
+	case "deploy":
+		if status, hasKey := mt.DeploymentsStatuses[id]; hasKey {
+			return fmt.Sprintf("Replicas:%d UpdatedReplicas:%d ReadyReplicas:%d AvailableReplicas:%d", status.DesiredReplicas, status.UpdatedReplicas, status.ReadyReplicas, status.AvailableReplicas)
+		}
+	case "sts":
+		if status, hasKey := mt.StatefulSetsStatuses[id]; hasKey {
+			return fmt.Sprintf("Replicas:%d ReadyReplicas:%d CurrentReplicas:%d UpdatedReplicas:%d", status.Replicas, status.ReadyReplicas, status.CurrentReplicas, status.UpdatedReplicas)
+		}
+	case "ds":
+		if status, hasKey := mt.DaemonSetsStatuses[id]; hasKey {
+			return fmt.Sprintf("CurrentNumberScheduled:%d NumberReady:%d NumberAvailable:%d NumberUnavailable:%d", status.CurrentNumberScheduled, status.NumberReady, status.NumberAvailable, status.NumberUnavailable)
+		}
+	case "job":
+		if status, hasKey := mt.JobsStatuses[id]; hasKey {
+			return fmt.Sprintf("Active:%d Succeeded:%d Failed:%d", status.Active, status.Succeeded, status.Failed)
+		}
+	}
+	return "status unavailable"
+}
+
+// resourcePodsStatuses returns the last statuses of the resource pods
+func (mt *multitracker) resourcePodsStatuses(id ResourceID) map[string]pod.PodStatus {
+	switch id.Kind {
+	case "po":
+		if status, hasKey := mt.PodsStatuses[id]; hasKey {
+			return map[string]pod.PodStatus{id.Name: status}
+		}
+	case "deploy":
+		return mt.DeploymentsStatuses[id].Pods
+	case "sts":
+		return mt.StatefulSetsStatuses[id].Pods
+	case "ds":
+		return mt.DaemonSetsStatuses[id].Pods
+	case "job":
+		return mt.JobsStatuses[id].Pods
+	}
+	return nil
+}
+
+// unschedulableTimeoutExceeded returns the pod of the resource which is unschedulable longer than UnschedulableTimeoutSeconds
+func (mt *multitracker) unschedulableTimeoutExceeded(id ResourceID, spec MultitrackSpec) (string, pod.SchedulingFailure, bool) {
+	if spec.UnschedulableTimeoutSeconds <= 0 {
+		return "", pod.SchedulingFailure{}, false
+	}
+
+	var podName string
+	var failure pod.SchedulingFailure
+	for name, status := range mt.resourcePodsStatuses(id) {
+		podFailure, ok := pod.GetSchedulingFailure(status.PodStatus)
+		if !ok {
+			continue
+		}
+		if podName == "" || podFailure.Since.Before(failure.Since) {
+			podName, failure = name, podFailure
+		}
+	}
+
+	if podName == "" || time.Since(failure.Since) < time.Duration(spec.UnschedulableTimeoutSeconds)*time.Second {
+		return "", pod.SchedulingFailure{}, false
+	}
+	return podName, failure, true
+}
+
+// checkResourcesTimeouts fails tracked resources which have exceeded TimeoutSeconds, NoProgressTimeoutSeconds
+// or UnschedulableTimeoutSeconds, and resources which failures budget is exceeded after FailureThresholdSeconds
+// have passed without new failures or status reports
+func (mt *multitracker) checkResourcesTimeouts() {
+	if mt.isDeployEnded {
+		return
+	}
+
+	for _, id := range mt.resourcesOrder {
+		timeouts, hasKey := mt.resourcesTimeouts[id]
+		if !hasKey || !mt.runningTrackers[id] || mt.isWaitingForDependencies(id) {
+			continue
+		}
+
+		state, hasKey := mt.resourcesStates(id.Kind)[id]
+		if !hasKey || state.IsFailed {
+			continue
+		}
+
+		spec := mt.resourcesSpecs(id.Kind)[id]
+
+		var reason string
+		if spec.TimeoutSeconds > 0 && time.Since(timeouts.TrackingStartedAt) >= time.Duration(spec.TimeoutSeconds)*time.Second {
+			reason = fmt.Sprintf("timeout %s exceeded", time.Duration(spec.TimeoutSeconds)*time.Second)
+		} else if spec.NoProgressTimeoutSeconds > 0 && time.Since(timeouts.LastProgressAt) >= time.Duration(spec.NoProgressTimeoutSeconds)*time.Second {
+			reason = fmt.Sprintf("no progress timeout %s exceeded", time.Duration(spec.NoProgressTimeoutSeconds)*time.Second)
+		} else if podName, failure, ok := mt.unschedulableTimeoutExceeded(id, spec); ok {
+			reason = fmt.Sprintf("po/%s unschedulable timeout %s exceeded: %s", podName, time.Duration(spec.UnschedulableTimeoutSeconds)*time.Second, failure.Summary())
+		} else if state.FailuresCount > 0 && state.isFailuresBudgetExceeded(spec) {
+			mt.failResourceByFailuresBudget(id)
+			continue
+		} else {
+			continue
+		}
+
+		mt.failResourceImmediately(id, fmt.Sprintf("%s, last status: %s", reason, mt.resourceStatusSummary(id)))
+	}
+}
+
+// failResourceByFailuresBudget fails running resource according to its FailMode when its failures budget is exceeded,
+// the failures are already counted
+func (mt *multitracker) failResourceByFailuresBudget(id ResourceID) {
+	states := mt.resourcesStates(id.Kind)
+	spec := mt.resourcesSpecs(id.Kind)[id]
+	state := states[id]
+
+	msg := fmt.Sprintf("%d failures exceeded allowed %d for %s failure threshold: %s", state.FailuresCount, *spec.AllowFailuresCount, time.Duration(*spec.FailureThresholdSeconds)*time.Second, state.LastFailureReason)
+	mt.printer.Message(mt.resourceEvent(id, display.FailedEvent).WithMessage(msg), fmt.Sprintf("# %s failed: %s", mt.resourceName(id), msg))
+
+	if err := mt.failResource(states, id, spec); err == tracker.StopTrack {
+		mt.stopTracker(id)
+	}
+}
+
+// failResourceImmediately fails running resource according to its FailMode regardless of failures budget
+func (mt *multitracker) failResourceImmediately(id ResourceID, reason string) {
+	states := mt.resourcesStates(id.Kind)
+	spec := mt.resourcesSpecs(id.Kind)[id]
+
+	mt.printer.Message(mt.resourceEvent(id, display.FailedEvent).WithMessage(reason), fmt.Sprintf("# %s failed: %s", mt.resourceName(id), reason))
+
+	states[id].LastFailureReason = reason
+	mt.addResourceFailure(id, "", reason)
+
+	if err := mt.failResource(states, id, spec); err == tracker.StopTrack {
+		mt.stopTracker(id)
+	}
+}
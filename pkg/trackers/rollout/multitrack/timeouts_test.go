@@ -0,0 +1,151 @@
+package multitrack
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/flant/kubedog/pkg/tracker/pod"
+)
+
+func TestCheckResourcesTimeouts(t *testing.T) {
+	unschedulablePod := func(since time.Time) pod.PodStatus {
+		return pod.PodStatus{PodStatus: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{{
+				Type:               corev1.PodScheduled,
+				Status:             corev1.ConditionFalse,
+				Reason:             corev1.PodReasonUnschedulable,
+				Message:            "0/3 nodes are available: 3 Insufficient cpu.",
+				LastTransitionTime: metav1.NewTime(since),
+			}},
+		}}
+	}
+
+	tests := []struct {
+		name string
+		spec MultitrackSpec
+		// startedAgo and lastProgressAgo shift the times of the tracking start and of the last progress
+		startedAgo      time.Duration
+		lastProgressAgo time.Duration
+		// unschedulableSince makes the pod unschedulable since the time
+		unschedulableSince time.Time
+		failuresCount      int
+		reason             string
+	}{
+		{
+			name:       "timeout",
+			spec:       MultitrackSpec{TimeoutSeconds: 60},
+			startedAgo: time.Minute,
+			reason:     "timeout 1m0s exceeded, last status: ",
+		},
+		{
+			name:       "timeout is not exceeded",
+			spec:       MultitrackSpec{TimeoutSeconds: 60},
+			startedAgo: 30 * time.Second,
+		},
+		{
+			name:            "no progress timeout",
+			spec:            MultitrackSpec{TimeoutSeconds: 600, NoProgressTimeoutSeconds: 60},
+			startedAgo:      2 * time.Minute,
+			lastProgressAgo: time.Minute,
+			reason:          "no progress timeout 1m0s exceeded",
+		},
+		{
+			name:            "no progress timeout is not exceeded",
+			spec:            MultitrackSpec{NoProgressTimeoutSeconds: 60},
+			startedAgo:      2 * time.Minute,
+			lastProgressAgo: 30 * time.Second,
+		},
+		{
+			name:               "unschedulable timeout",
+			spec:               MultitrackSpec{UnschedulableTimeoutSeconds: 60},
+			unschedulableSince: time.Now().Add(-time.Minute),
+			reason:             "po/mypod unschedulable timeout 1m0s exceeded: 0/3 nodes available: 3 Insufficient cpu",
+		},
+		{
+			name:               "unschedulable timeout is not exceeded",
+			spec:               MultitrackSpec{UnschedulableTimeoutSeconds: 60},
+			unschedulableSince: time.Now(),
+		},
+		{
+			name:          "failures budget after failure threshold",
+			spec:          MultitrackSpec{AllowFailuresCount: new(int), FailureThresholdSeconds: intPtr(60)},
+			failuresCount: 1,
+			reason:        "ImagePullBackOff: back-off pulling image",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mt, id, out := newTestMultitracker(test.spec)
+			opts := mt.startTracker(context.Background(), id, mt.trackersOptions)
+
+			timeouts := mt.resourcesTimeouts[id]
+			timeouts.TrackingStartedAt = timeouts.TrackingStartedAt.Add(-test.startedAgo)
+			timeouts.LastProgressAt = timeouts.LastProgressAt.Add(-test.lastProgressAgo)
+			if !test.unschedulableSince.IsZero() {
+				mt.PodsStatuses[id] = unschedulablePod(test.unschedulableSince)
+			}
+
+			state := mt.TrackingPods[id]
+			for i := 0; i < test.failuresCount; i++ {
+				checkError(t, handlePodFailure(mt, id), "")
+				state.FailuresWindowStartedAt = state.FailuresWindowStartedAt.Add(-time.Minute)
+			}
+
+			mt.checkResourcesTimeouts()
+
+			if state.IsFailed != (test.reason != "") {
+				t.Fatalf("expected resource failed %v, got output %q", test.reason != "", out.String())
+			}
+			if test.reason == "" {
+				return
+			}
+
+			if !strings.Contains(state.LastFailureReason, test.reason) {
+				t.Errorf("expected failure reason %q, got %q", test.reason, state.LastFailureReason)
+			}
+			if err := opts.ParentContext.Err(); err == nil {
+				t.Errorf("expected tracker of the failed resource to be stopped")
+			}
+		})
+	}
+}
+
+func TestHandleResourceProgress(t *testing.T) {
+	mt, id, _ := newTestMultitracker(MultitrackSpec{NoProgressTimeoutSeconds: 60})
+	mt.startTracker(context.Background(), id, mt.trackersOptions)
+
+	timeouts := mt.resourcesTimeouts[id]
+	pendingAt := time.Now().Add(-time.Hour)
+	timeouts.LastProgressAt = pendingAt
+
+	mt.PodsStatuses[id] = pod.PodStatus{PodStatus: corev1.PodStatus{Phase: corev1.PodPending}}
+	mt.handleResourceProgress(id)
+	if !timeouts.LastProgressAt.After(pendingAt) {
+		t.Fatalf("expected the first status to be a progress")
+	}
+
+	// Restarts are not a progress
+	progressAt := timeouts.LastProgressAt.Add(-time.Hour)
+	timeouts.LastProgressAt = progressAt
+	mt.PodsStatuses[id] = pod.PodStatus{PodStatus: corev1.PodStatus{
+		Phase:             corev1.PodPending,
+		ContainerStatuses: []corev1.ContainerStatus{{Name: "main", RestartCount: 3}},
+	}}
+	mt.handleResourceProgress(id)
+	if !timeouts.LastProgressAt.Equal(progressAt) {
+		t.Errorf("expected container restarts not to be a progress")
+	}
+
+	mt.PodsStatuses[id] = readyPodStatus()
+	mt.handleResourceProgress(id)
+	if !timeouts.LastProgressAt.After(progressAt) {
+		t.Errorf("expected ready pod to be a progress")
+	}
+}
@@ -1,5 +1,11 @@
 package multitrack
 
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/flant/kubedog/pkg/tracker/pod"
+)
+
 func appendElemIfNotExist(arr []string, newElem string) []string {
 	for _, elem := range arr {
 		if elem == newElem {
@@ -20,3 +26,22 @@ func deleteElemFromArray(arr []string, elemToDelete string) []string {
 
 	return newArr
 }
+
+// isPodRecovered returns true when pod is ready, succeeded or gone
+func isPodRecovered(status pod.PodStatus) bool {
+	if status.Phase == "" || status.Phase == corev1.PodSucceeded {
+		return true
+	}
+
+	return isPodReady(status)
+}
+
+func isPodReady(status pod.PodStatus) bool {
+	for _, cond := range status.Conditions {
+		if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
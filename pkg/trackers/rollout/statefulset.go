@@ -16,39 +16,69 @@ import (
 // Exit on DaemonSet ready or on errors
 func TrackStatefulSetTillReady(name, namespace string, kube kubernetes.Interface, opts tracker.Options) error {
 	feed := statefulset.NewFeed()
+	resource := display.Event{Kind: "sts", Namespace: namespace, Name: name}
+	printer := opts.GetPrinter()
+	var lastTermination *containerTermination
 
 	feed.OnAdded(func(ready bool) error {
 		if ready {
-			fmt.Fprintf(display.Out, "# sts/%s appears to be ready\n", name)
+			printer.Message(resource.WithType(display.ReadyEvent), fmt.Sprintf("# sts/%s appears to be ready", name))
 			return tracker.StopTrack
 		}
 
-		fmt.Fprintf(display.Out, "# sts/%s added\n", name)
+		printer.Message(resource.WithType(display.AddedEvent), fmt.Sprintf("# sts/%s added", name))
 		return nil
 	})
 	feed.OnReady(func() error {
-		fmt.Fprintf(display.Out, "# sts/%s become READY\n", name)
+		printer.Message(resource.WithType(display.ReadyEvent), fmt.Sprintf("# sts/%s become READY", name))
 		return tracker.StopTrack
 	})
 	feed.OnFailed(func(reason string) error {
-		fmt.Fprintf(display.Out, "# sts/%s FAIL: %s\n", name, reason)
+		reason = withLastContainerTermination(reason, lastTermination)
+		printer.Message(resource.WithType(display.FailedEvent).WithMessage(reason), fmt.Sprintf("# sts/%s FAIL: %s", name, reason))
 		return tracker.ResourceErrorf("failed: %s", reason)
 	})
 	feed.OnEventMsg(func(msg string) error {
-		fmt.Fprintf(display.Out, "# sts/%s event: %s\n", name, msg)
+		printer.Message(resource.WithType(display.EventMsgEvent).WithMessage(msg), fmt.Sprintf("# sts/%s event: %s", name, msg))
 		return nil
 	})
 	feed.OnAddedPod(func(pod replicaset.ReplicaSetPod) error {
-		fmt.Fprintf(display.Out, "# sts/%s po/%s added\n", name, pod.Name)
+		printer.Message(resource.WithType(display.AddedPodEvent).WithPod(pod.Name, ""), fmt.Sprintf("# sts/%s po/%s added", name, pod.Name))
 		return nil
 	})
 	feed.OnPodError(func(podError replicaset.ReplicaSetPodError) error {
-		fmt.Fprintf(display.Out, "# sts/%s %s %s error: %s\n", name, podError.PodName, podError.ContainerName, podError.Message)
-		return tracker.ResourceErrorf("sts/%s %s %s failed: %s", name, podError.PodName, podError.ContainerName, podError.Message)
+		event := resource.WithType(display.PodErrorEvent).WithPod(podError.PodName, podError.ContainerName).WithMessage(podError.Message)
+		printer.Message(event, fmt.Sprintf("# sts/%s %s %s error: %s", name, podError.PodName, podError.ContainerName, podError.Message))
+		message := withLastContainerTermination(podError.Message, lastTermination)
+		return tracker.ResourceErrorf("sts/%s %s %s failed: %s", name, podError.PodName, podError.ContainerName, message)
 	})
 	feed.OnPodLogChunk(func(chunk *replicaset.ReplicaSetPodLogChunk) error {
 		header := fmt.Sprintf("po/%s %s", chunk.PodName, chunk.ContainerName)
-		display.OutputLogLines(header, chunk.LogLines)
+		printer.LogLines(resource.WithPod(chunk.PodName, chunk.ContainerName), header, chunk.LogLines)
+		return nil
+	})
+	feed.OnPodContainerTermination(func(termination replicaset.ReplicaSetPodContainerTermination) error {
+		lastTermination = &containerTermination{PodName: termination.PodName, ContainerName: termination.ContainerName, Summary: termination.Summary()}
+		event := resource.WithType(display.ContainerTerminatedEvent).WithPod(termination.PodName, termination.ContainerName).WithMessage(termination.Summary())
+		printer.Message(event, fmt.Sprintf("# sts/%s po/%s %s terminated: %s", name, termination.PodName, termination.ContainerName, termination.Summary()))
+		if len(termination.LogLines) > 0 {
+			header := fmt.Sprintf("po/%s %s (terminated)", termination.PodName, termination.ContainerName)
+			printer.LogLines(resource.WithPod(termination.PodName, termination.ContainerName), header, termination.LogLines)
+		}
+		return nil
+	})
+	feed.OnPodUnschedulable(func(failure replicaset.ReplicaSetPodSchedulingFailure) error {
+		event := resource.WithType(display.UnschedulableEvent).WithPod(failure.PodName, "").WithMessage(failure.Message)
+		printer.Message(event, fmt.Sprintf("# sts/%s po/%s unschedulable: %s", name, failure.PodName, failure.Summary()))
+		return nil
+	})
+	feed.OnPodContainerRestart(func(restart replicaset.ReplicaSetPodContainerRestart) error {
+		event := resource.WithType(display.ContainerRestartedEvent).WithPod(restart.PodName, restart.ContainerName).WithMessage(restart.Summary())
+		printer.Message(event, fmt.Sprintf("# sts/%s po/%s %s restarted: %s", name, restart.PodName, restart.ContainerName, restart.Summary()))
+		return nil
+	})
+	feed.OnStatusReport(func(status statefulset.StatefulSetStatus) error {
+		printer.ResourceStatus(resource.WithType(display.StatusReportEvent).WithStatus(status))
 		return nil
 	})
 
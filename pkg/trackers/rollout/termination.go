@@ -0,0 +1,22 @@
+package rollout
+
+import (
+	"fmt"
+	"strings"
+)
+
+// containerTermination is the last crashed container instance of the tracked resource
+type containerTermination struct {
+	PodName       string
+	ContainerName string
+	Summary       string
+}
+
+// withLastContainerTermination adds the last crashed container instance details to the failure message.
+// Message is returned as is when it already contains these details, e.g. CrashLoopBackOff error.
+func withLastContainerTermination(message string, termination *containerTermination) string {
+	if termination == nil || strings.Contains(message, termination.Summary) {
+		return message
+	}
+	return fmt.Sprintf("%s (last container termination: po/%s %s: %s)", message, termination.PodName, termination.ContainerName, termination.Summary)
+}
@@ -0,0 +1,40 @@
+package rollout
+
+import "testing"
+
+func TestWithLastContainerTermination(t *testing.T) {
+	termination := &containerTermination{PodName: "mypod", ContainerName: "main", Summary: "exit code 137, reason OOMKilled"}
+
+	tests := []struct {
+		name        string
+		message     string
+		termination *containerTermination
+		expected    string
+	}{
+		{
+			name:     "no termination",
+			message:  "ImagePullBackOff: back-off pulling image",
+			expected: "ImagePullBackOff: back-off pulling image",
+		},
+		{
+			name:        "termination added",
+			message:     "pod is in Failed phase",
+			termination: termination,
+			expected:    "pod is in Failed phase (last container termination: po/mypod main: exit code 137, reason OOMKilled)",
+		},
+		{
+			name:        "message with the same termination",
+			message:     "CrashLoopBackOff: back-off 10s (last termination: exit code 137, reason OOMKilled)",
+			termination: termination,
+			expected:    "CrashLoopBackOff: back-off 10s (last termination: exit code 137, reason OOMKilled)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if message := withLastContainerTermination(test.message, test.termination); message != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, message)
+			}
+		})
+	}
+}